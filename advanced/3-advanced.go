@@ -6,16 +6,30 @@
 // Methods
 // Interfaces
 // Errors
-package main
+package advanced
 
 import (
 	"errors"
 	"fmt"
 	"math"
+
+	"github.com/omussell/go-by-example/section"
 )
 
-func advanced() {
-	// Variadic Functions
+// Sections are the examples in this file, in reading order.
+var Sections = []section.Section{
+	{Title: "Variadic Functions", Run: VariadicFunctions},
+	{Title: "Anonymous Functions and Closures", Run: Closures},
+	{Title: "Recursion", Run: Recursion},
+	{Title: "Pointers", Run: Pointers},
+	{Title: "Structs", Run: Structs},
+	{Title: "Methods", Run: Methods},
+	{Title: "Interfaces", Run: Interfaces},
+	{Title: "Errors", Run: Errors},
+}
+
+// Variadic Functions
+func VariadicFunctions() {
 	/*
 	   Call functions with any number of trailing
 	   arguments.
@@ -25,8 +39,10 @@ func advanced() {
 
 	nums := []int{1, 2, 3, 4}
 	sum(nums...) // Prints [1 2 3 4] 10
+}
 
-	// Anonymous Functions and Closures
+// Anonymous Functions and Closures
+func Closures() {
 	/*
 	   Anonymous functions are useful when you want to define a function inline without having to name it.
 
@@ -43,14 +59,18 @@ func advanced() {
 
 	newInts := intSeq()
 	fmt.Println(newInts()) // Prints 1
+}
 
-	// Recursion
+// Recursion
+func Recursion() {
 	/*
 	   Function calls itself until it reaches fact(0)
 	*/
 	fmt.Println(fact(7)) // Prints 5040
+}
 
-	// Pointers
+// Pointers
+func Pointers() {
 	/*
 	   Pointers allow you to pass references to values
 	*/
@@ -68,9 +88,11 @@ func advanced() {
 	   Assigning a value to a dereferenced pointer changes the value at the referenced address.
 	*/
 
-	fmt.Println("pointer:", &i) // Prints pointer: 0x42131100
+	fmt.Println("pointer:", &i) // Prints pointer: 0xc000012345
+}
 
-	// Structs
+// Structs
+func Structs() {
 	/*
 	   Structs are typed collections of fields. Useful for grouping data together to form records.
 	*/
@@ -100,23 +122,26 @@ func advanced() {
 	   50
 	   51
 	*/
+}
 
-	// Methods
-
+// Methods
+func Methods() {
 	r := rect{width: 10, height: 5}
 
-	fmt.Println("area: ", r.area())   // Prints area: 50
-	fmt.Println("perim: ", r.perim()) // Prints perim: 30
+	fmt.Println("area: ", r.area())   // Prints area:  50
+	fmt.Println("perim: ", r.perim()) // Prints perim:  30
 
 	rp := &r
-	fmt.Println("area: ", rp.area())   // Prints area: 50
-	fmt.Println("perim: ", rp.perim()) // Prints perim: 30
+	fmt.Println("area: ", rp.area())   // Prints area:  50
+	fmt.Println("perim: ", rp.perim()) // Prints perim:  30
 
 	/*
 	   You may want to use a pointer receiver type to avoid copying on method calls or to allow the method to mutate the receiving struct.
 	*/
+}
 
-	// Interfaces
+// Interfaces
+func Interfaces() {
 	/*
 	   Interfaces are named collections of method signatures
 
@@ -136,11 +161,13 @@ func advanced() {
 	   12
 	   14
 	   {5}
-	   78.5398
-	   31.4159
+	   78.53981633974483
+	   31.41592653589793
 	*/
+}
 
-	// Errors
+// Errors
+func Errors() {
 	for _, i := range []int{7, 42} {
 		if res, e := f1(i); e != nil {
 			fmt.Println("f1 failed:", e)
@@ -165,11 +192,11 @@ func advanced() {
 	/*
 	   Prints:
 	   f1 worked: 10
-	   f1 failed: Cant work with 42
+	   f1 failed: cant work with 42
 	   f2 worked: 10
-	   f2 failed: 42 - Cant work with it
+	   f2 failed: 42 - cant work with it
 	   42
-	   Cant work with it
+	   cant work with it
 	*/
 }
 
@@ -270,7 +297,7 @@ func measure(g geometry) {
 // Errors
 func f1(arg int) (int, error) {
 	if arg == 42 {
-		return -1, errors.New("Cant work with 42")
+		return -1, errors.New("cant work with 42")
 	}
 	return arg + 3, nil
 }
@@ -286,7 +313,7 @@ func (e *argError) Error() string {
 
 func f2(arg int) (int, error) {
 	if arg == 42 {
-		return -1, &argError{arg, "Cant work with it"}
+		return -1, &argError{arg, "cant work with it"}
 	}
 	return arg + 3, nil
 }
@@ -0,0 +1,84 @@
+// Code generated by "go run . examples -w" from the Prints comments. DO NOT EDIT.
+
+package advanced_test
+
+import (
+	"github.com/omussell/go-by-example/advanced"
+	"github.com/omussell/go-by-example/clock"
+)
+
+func ExampleVariadicFunctions() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	advanced.VariadicFunctions()
+	// Output:
+	// [1 2] 3
+	// [1 2 3] 6
+	// [1 2 3 4] 10
+}
+
+func ExampleClosures() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	advanced.Closures()
+	// Output:
+	// 1
+	// 2
+	// 3
+	// 1
+}
+
+func ExampleRecursion() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	advanced.Recursion()
+	// Output:
+	// 5040
+}
+
+// Pointers is left out as it prints a pointer address.
+
+func ExampleStructs() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	advanced.Structs()
+	// Output:
+	// {Bob 20}
+	// {Alice 30}
+	// {Fred 0}
+	// &{Ann 40}
+	// &{Jon 42}
+	// Sean
+	// 50
+	// 51
+}
+
+func ExampleMethods() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	advanced.Methods()
+	// Output:
+	// area:  50
+	// perim:  30
+	// area:  50
+	// perim:  30
+}
+
+func ExampleInterfaces() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	advanced.Interfaces()
+	// Output:
+	// {3 4}
+	// 12
+	// 14
+	// {5}
+	// 78.53981633974483
+	// 31.41592653589793
+}
+
+func ExampleErrors() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	advanced.Errors()
+	// Output:
+	// f1 worked: 10
+	// f1 failed: cant work with 42
+	// f2 worked: 10
+	// f2 failed: 42 - cant work with it
+	// 42
+	// cant work with it
+}
@@ -0,0 +1,266 @@
+// Goroutines
+// Channels
+// Channel Buffering
+// Channel Synchronization
+// Timers
+// Resetting Timers
+// Tickers
+
+package async
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/omussell/go-by-example/clock"
+	"github.com/omussell/go-by-example/section"
+)
+
+// Sections are the examples in this file, in reading order.
+var Sections = []section.Section{
+	{Title: "Goroutines", Run: Goroutines},
+	{Title: "Channels", Run: Channels},
+	{Title: "Channel Buffering", Run: ChannelBuffering},
+	{Title: "Channel Synchronization", Run: ChannelSynchronization},
+	{Title: "Timers", Run: Timers},
+	{Title: "Resetting Timers", Run: ResettingTimers},
+	{Title: "Tickers", Run: Tickers},
+}
+
+// Goroutines
+func Goroutines() {
+	/*
+	   Lightweight thread of execution
+	*/
+
+	f("direct") // Prints direct : 0 \n direct : 1 \n direct : 2. Run the function as usual, synchronously
+
+	go f("goroutine") // Invoke as a goroutine.
+
+	go func(msg string) {
+		fmt.Println(msg)
+	}("going") // Can also start a goroutine for an anonymous function
+
+	clock.Sleep(time.Second) // The two functions calls are running asynchronously in separate goroutines now. clock.Sleep is time.Sleep, except when checking the examples, when no time really passes.
+	/*
+	   Prints, in any order:
+	   goroutine : 0
+	   going
+	   goroutine : 1
+	   goroutine : 2
+	*/
+
+	fmt.Println("done") // Prints done
+}
+
+// Channels
+func Channels() {
+	/*
+	   Channels are the pipes that connect concurrent goroutines. You can send values into channels from one goroutine and receive those values into another goroutine.
+	*/
+
+	messages := make(chan string) // Create a new channel with make(chan val-type). Channels are typed by the values they convey.
+
+	go func() { messages <- "ping" }() // Send a value into a channel using the channel <- syntax.
+
+	msg := <-messages // The <-channel syntax receives a value from the channel.
+	fmt.Println(msg)  // Prints ping
+
+	/*
+	   Sends and receives block until both the sender and receiver are ready. This allows us to wait at the end of the program for the message without having to use any other synchronisation.
+	*/
+}
+
+// Channel Buffering
+func ChannelBuffering() {
+	/*
+	   By default channels are unbuffered, they will only accept sends (chan <-) if there is a corresponding receive (<-chan) ready to receive the sent value. Buffered channels accept a limited number of values without a corresponding receiver for those values.
+	*/
+
+	mssgs := make(chan string, 2) // Make a channel of strings buffering up to 2 values
+
+	mssgs <- "buffered" // This channel is buffered so we can send these values into a channel without the corresponding concurrent receieve
+	mssgs <- "channel"
+
+	fmt.Println(<-mssgs) // Receive the values
+	fmt.Println(<-mssgs)
+
+	/*
+	   Prints:
+	   buffered
+	   channel
+	*/
+}
+
+// Channel Synchronization
+func ChannelSynchronization() {
+	/*
+	   We can use channels to synchronize execution across goroutines.
+	*/
+
+	done := make(chan bool, 1)
+	go worker(done) // Start a worker goroutine, giving it the channel to notify on.
+
+	<-done // Block until we receive a notification from the worker on the channel
+	// Prints working...done
+}
+
+// Timers
+func Timers() {
+	/*
+	   A timer fires once, at some point in the future, by sending the time on its channel C. clock.NewTimer is time.NewTimer, except when the examples are checked, when the timers are virtual and no time really passes. The same goes for the rest of the clock functions below.
+	*/
+	start := clock.Now()
+	timer1 := clock.NewTimer(2 * time.Second)
+	<-timer1.C                                             // Blocks until the timer fires
+	fmt.Println("Timer 1 fired after", clock.Since(start)) // Prints Timer 1 fired after 2s
+
+	/*
+	   Unlike a sleep, a timer can be stopped before it fires. Stop returns whether it did stop it, or false if the timer had already fired.
+	*/
+	timer2 := clock.NewTimer(time.Second)
+	go func() {
+		<-timer2.C
+		fmt.Println("Timer 2 fired") // Never printed, as the timer is stopped first
+	}()
+	if timer2.Stop() {
+		fmt.Println("Timer 2 stopped") // Prints Timer 2 stopped
+	}
+	clock.Sleep(2 * time.Second) // Long enough for timer 2 to have fired, if it was going to
+	fmt.Println(timer2.Stop())   // Prints false. It was already stopped
+
+	/*
+	   AfterFunc calls a function in its own goroutine when the timer fires, instead of sending on a channel.
+	*/
+	done := make(chan bool)
+	clock.AfterFunc(500*time.Millisecond, func() {
+		fmt.Println("AfterFunc called after", clock.Since(start)) // Prints AfterFunc called after 4.5s
+		done <- true
+	})
+	<-done
+}
+
+// Resetting Timers
+func ResettingTimers() {
+	/*
+	   Reset changes when a timer fires. Since Go 1.23, in modules whose go.mod says go 1.23 or later, as this one does, a timer that fired but whose time nobody received counts as not having fired, so Stop and Reset return true for it, and the time is dropped rather than left to be received.
+	*/
+	start := clock.Now()
+	t := clock.NewTimer(time.Second)
+	clock.Sleep(2 * time.Second)      // The timer fires, but nothing receives from it
+	fmt.Println(t.Reset(time.Second)) // Prints true
+	fired := <-t.C
+	fmt.Println("received after", clock.Since(start), "a time from", fired.Sub(start)) // Prints received after 3s a time from 3s
+
+	/*
+	   Before Go 1.23, or in modules that say an earlier version, the time stayed in C, and the receive after Reset got it straight away, so code written for those versions stops the timer and drains C before Reset. The drain has to be a select with a default, as in newer versions, or when the time was already received, there is nothing in C and a plain receive would block forever.
+	*/
+	start = clock.Now()
+	t = clock.NewTimer(time.Second)
+	<-t.C
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+	t.Reset(time.Second)
+	fired = <-t.C
+	fmt.Println("received after", clock.Since(start), "a time from", fired.Sub(start)) // Prints received after 2s a time from 2s
+
+	/*
+	   A timer is the way to time out a loop that waits for something, and Reset puts the timeout back each time it gets it. Here the messages stop coming after three, and the timeout of a second fires.
+	*/
+	messages := make(chan int)
+	go func() {
+		for i := 1; i <= 3; i++ {
+			clock.Sleep(700 * time.Millisecond)
+			messages <- i
+		}
+	}()
+	start = clock.Now()
+	timeout := clock.NewTimer(time.Second)
+	defer timeout.Stop()
+	for {
+		select {
+		case m := <-messages:
+			fmt.Println("message", m, "at", clock.Since(start))
+			timeout.Reset(time.Second)
+		case <-timeout.C:
+			fmt.Println("timed out at", clock.Since(start))
+			return
+		}
+	}
+	/*
+	   Prints:
+	   message 1 at 700ms
+	   message 2 at 1.4s
+	   message 3 at 2.1s
+	   timed out at 3.1s
+	*/
+}
+
+// Tickers
+func Tickers() {
+	/*
+	   A ticker fires again and again, every period, until it is stopped. If whatever is receiving is slow, ticks are dropped rather than queued up.
+	*/
+	start := clock.Now()
+	ticker := clock.NewTicker(500 * time.Millisecond)
+	done := make(chan bool)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case t := <-ticker.C:
+				fmt.Println("Tick at", t.Sub(start))
+			}
+		}
+	}()
+
+	clock.Sleep(1600 * time.Millisecond)
+	ticker.Stop() // Stop doesn't close C, so the goroutine needs telling to finish
+	done <- true
+	fmt.Println("Ticker stopped")
+	/*
+	   Prints:
+	   Tick at 500ms
+	   Tick at 1s
+	   Tick at 1.5s
+	   Ticker stopped
+	*/
+
+	/*
+	   time.Tick returns the channel of a ticker that can't be stopped, to range over as in for t := range time.Tick(time.Second). Before Go 1.23, a ticker that wasn't stopped was never garbage collected, so calling Tick in a function that returns, or in a loop, leaked a ticker each time. Now it is collected once nothing refers to its channel. Still, Tick is best kept for something that runs as long as the program does. Anywhere else, NewTicker and defer Stop, as ticks does, makes it clear when the ticker finishes, and doesn't leak with older versions of Go.
+	*/
+	fmt.Println(ticks(3, time.Second)) // Prints 3s
+}
+
+// ticks waits for n ticks, d apart, and returns how long that took.
+func ticks(n int, d time.Duration) time.Duration {
+	start := clock.Now()
+	ticker := clock.NewTicker(d)
+	defer ticker.Stop()
+	for i := 0; i < n; i++ {
+		<-ticker.C
+	}
+	return clock.Since(start)
+}
+
+func f(from string) {
+	for i := 0; i < 3; i++ {
+		fmt.Println(from, ":", i)
+	}
+}
+
+func worker(done chan bool) {
+	/*
+	   This is the function we'll run in a goroutine. The done channel will be used to notify another goroutine that this functions work is done.
+	*/
+	fmt.Print("working...")
+	clock.Sleep(time.Second)
+	fmt.Println("done")
+
+	done <- true
+}
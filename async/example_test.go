@@ -0,0 +1,78 @@
+// Code generated by "go run . examples -w" from the Prints comments. DO NOT EDIT.
+
+package async_test
+
+import (
+	"github.com/omussell/go-by-example/async"
+	"github.com/omussell/go-by-example/clock"
+)
+
+func ExampleGoroutines() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	async.Goroutines()
+	// Unordered output:
+	// direct : 0
+	// direct : 1
+	// direct : 2
+	// goroutine : 0
+	// going
+	// goroutine : 1
+	// goroutine : 2
+	// done
+}
+
+func ExampleChannels() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	async.Channels()
+	// Output:
+	// ping
+}
+
+func ExampleChannelBuffering() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	async.ChannelBuffering()
+	// Output:
+	// buffered
+	// channel
+}
+
+func ExampleChannelSynchronization() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	async.ChannelSynchronization()
+	// Output:
+	// working...done
+}
+
+func ExampleTimers() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	async.Timers()
+	// Output:
+	// Timer 1 fired after 2s
+	// Timer 2 stopped
+	// false
+	// AfterFunc called after 4.5s
+}
+
+func ExampleResettingTimers() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	async.ResettingTimers()
+	// Output:
+	// true
+	// received after 3s a time from 3s
+	// received after 2s a time from 2s
+	// message 1 at 700ms
+	// message 2 at 1.4s
+	// message 3 at 2.1s
+	// timed out at 3.1s
+}
+
+func ExampleTickers() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	async.Tickers()
+	// Output:
+	// Tick at 500ms
+	// Tick at 1s
+	// Tick at 1.5s
+	// Ticker stopped
+	// 3s
+}
@@ -8,7 +8,7 @@
 // If/Else
 // Switch
 // Arrays
-package main
+package basics
 
 // import "fmt" // Single import
 /*
@@ -22,25 +22,41 @@ import (
 	"fmt"
 	"math"
 	"time"
+
+	"github.com/omussell/go-by-example/clock"
+	"github.com/omussell/go-by-example/section"
 )
 
 // Constants
 const s string = "constant"
 
-func basics() {
-	fmt.Println("hello world") // Print string
+// Sections are the examples in this file, in reading order.
+var Sections = []section.Section{
+	{Title: "Values", Run: Values},
+	{Title: "Variables", Run: Variables},
+	{Title: "Constants", Run: Constants},
+	{Title: "Loops", Run: Loops},
+	{Title: "If/Else", Run: IfElse},
+	{Title: "Switch", Run: Switch},
+	{Title: "Arrays", Run: Arrays},
+}
 
-	// Values
-	fmt.Println("go" + "lang") // Add strings together
+// Values
+func Values() {
+	fmt.Println("hello world") // Prints hello world
 
-	fmt.Println("1+1 =", 1+1)         // Add integers
-	fmt.Println("7.0/3.0 =", 7.0/3.0) // Divide floats
+	fmt.Println("go" + "lang") // Prints golang. Add strings together
 
-	fmt.Println(true && false) // false
-	fmt.Println(true || false) // true
-	fmt.Println(!true)         // false
+	fmt.Println("1+1 =", 1+1)         // Prints 1+1 = 2. Add integers
+	fmt.Println("7.0/3.0 =", 7.0/3.0) // Prints 7.0/3.0 = 2.3333333333333335. Divide floats
+
+	fmt.Println(true && false) // Prints false
+	fmt.Println(true || false) // Prints true
+	fmt.Println(!true)         // Prints false
+}
 
-	// Variables
+// Variables
+func Variables() {
 	var a = "initial"
 	fmt.Println(a) // prints initial
 
@@ -57,8 +73,10 @@ func basics() {
 	fmt.Println(f) // Prints apple. := is shorthand for declaring and initialising a variable. This is the same as saying `var f string = "apple"`
 
 	// := can only be used inside a function. var can be used inside and outside of functions. Its just shorthand to make it easier to declare variables.
+}
 
-	// Constants
+// Constants
+func Constants() {
 	fmt.Println(s) // prints constant
 
 	const x = 500000000 // Constants can appear anywhere a var statement can
@@ -69,8 +87,10 @@ func basics() {
 	fmt.Println(int64(g)) // Prints 600000000000. A numeric constant has no type until its given one, like by an explicit conversion
 
 	fmt.Println(math.Sin(x)) // Prints -0.28470407323754404. A number can be given a type by using it in a context that requires one, such as a variable assignment or function call. Here, math.Sin expects a float64.
+}
 
-	// Loops
+// Loops
+func Loops() {
 	// The only type of loop is `for`. There is no `while` etc. just variations on `for`.
 	i := 1
 	for i <= 3 {
@@ -115,8 +135,10 @@ func basics() {
 	   3
 	   5
 	*/
+}
 
-	// If/Else
+// If/Else
+func IfElse() {
 	if 7%2 == 0 {
 		fmt.Println("7 is even")
 	} else {
@@ -134,8 +156,10 @@ func basics() {
 	} else {
 		fmt.Println(num, "has multiple digits")
 	} // Prints 9 has 1 digit. A statement can precede conditionals. Any variables declared in this statement are available in all branches.
+}
 
-	// Switch
+// Switch
+func Switch() {
 	k := 2
 	fmt.Print("Write ", k, " as ")
 	switch k {
@@ -147,14 +171,16 @@ func basics() {
 		fmt.Println("three")
 	} // Prints Write 2 as two. Basic switch.
 
-	switch time.Now().Weekday() {
+	// clock.Now is time.Now, except when checking the examples, when it is
+	// always 23:00 on a Tuesday. Try go run . run --now "Saturday 09:00" switch
+	switch clock.Now().Weekday() {
 	case time.Saturday, time.Sunday:
 		fmt.Println("Its the weekend")
 	default:
 		fmt.Println("Its a weekday")
 	} // Prints Its a weekday. Commas separate multiple expressions in the same case statement.
 
-	l := time.Now()
+	l := clock.Now()
 	switch {
 	case l.Hour() < 12:
 		fmt.Println("Its before noon")
@@ -180,10 +206,12 @@ func basics() {
 	   Im a bool
 	   Im an int
 	   Dont know type string
-	   A type switch compares types instead of values.
 	*/
+	// A type switch compares types instead of values.
+}
 
-	// Arrays
+// Arrays
+func Arrays() {
 	var n [5]int
 	fmt.Println("emp:", n) // Prints emp: [0 0 0 0 0]
 	/*
@@ -208,5 +236,4 @@ func basics() {
 	/*
 	   Arrays are one dimensional but you can compose types to build multidimensional data structures.
 	*/
-
 }
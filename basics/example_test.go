@@ -0,0 +1,91 @@
+// Code generated by "go run . examples -w" from the Prints comments. DO NOT EDIT.
+
+package basics_test
+
+import (
+	"github.com/omussell/go-by-example/basics"
+	"github.com/omussell/go-by-example/clock"
+)
+
+func ExampleValues() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	basics.Values()
+	// Output:
+	// hello world
+	// golang
+	// 1+1 = 2
+	// 7.0/3.0 = 2.3333333333333335
+	// false
+	// true
+	// false
+}
+
+func ExampleVariables() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	basics.Variables()
+	// Output:
+	// initial
+	// 1 2
+	// true
+	// 0
+	// apple
+}
+
+func ExampleConstants() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	basics.Constants()
+	// Output:
+	// constant
+	// 6e+11
+	// 600000000000
+	// -0.28470407323754404
+}
+
+func ExampleLoops() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	basics.Loops()
+	// Output:
+	// 1
+	// 2
+	// 3
+	// 7
+	// 8
+	// 9
+	// loop
+	// 1
+	// 3
+	// 5
+}
+
+func ExampleIfElse() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	basics.IfElse()
+	// Output:
+	// 7 is odd
+	// 8 is divisible by 4
+	// 9 has 1 digit
+}
+
+func ExampleSwitch() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	basics.Switch()
+	// Output:
+	// Write 2 as two
+	// Its a weekday
+	// Its after noon
+	// Im a bool
+	// Im an int
+	// Dont know type string
+}
+
+func ExampleArrays() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	basics.Arrays()
+	// Output:
+	// emp: [0 0 0 0 0]
+	// set: [0 0 0 0 100]
+	// get: 100
+	// len: 5
+	// dcl: [1 2 3 4 5]
+	// 2d:  [[0 1 2] [1 2 3]]
+}
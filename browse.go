@@ -0,0 +1,483 @@
+// Terminal browser
+//
+// The browse command lists the sections in a sidebar, with the code of the
+// selected one and its explanation beside it. Running a section shows its
+// output as it is written, so the examples that sleep can be watched as they
+// go. It is driven from the keyboard and only needs the common ANSI escape
+// sequences, so it works in a plain terminal over SSH.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/omussell/go-by-example/section"
+)
+
+const browseKeys = "j/k move  enter run  space/b scroll  o output  q quit"
+
+// ANSI escape sequences for the classes of highlighted code.
+var ansiClasses = map[string]string{
+	"kw":  "\x1b[1m",
+	"str": "\x1b[32m",
+	"num": "\x1b[34m",
+	"com": "\x1b[2m",
+	"pre": "\x1b[35m",
+}
+
+const (
+	ansiReset   = "\x1b[0m"
+	ansiReverse = "\x1b[7m"
+	ansiBold    = "\x1b[1m"
+	ansiDoc     = "\x1b[36m"
+)
+
+// A browser is the state of the terminal browser.
+type browser struct {
+	tty        *os.File // The terminal, kept while os.Stdout captures a section
+	cols, rows int
+
+	sections []section.Section
+	sidebar  []sidebarRow
+	sel      int // Selected section
+	top      int // First sidebar row shown
+	scroll   int // First line of code shown
+	full     bool
+
+	codes   map[string][]string // Rendered code by section ID, for codeWidth
+	width   int
+	outputs map[string]*browserOutput // By section ID
+	running bool
+	status  string
+}
+
+// A sidebarRow is either a topic heading or a section.
+type sidebarRow struct {
+	topic string
+	sec   int // Index into sections, or -1 for a heading
+}
+
+// browserOutput is what a section printed when it was last run.
+type browserOutput struct {
+	text    []byte
+	running bool
+}
+
+func newBrowser(tty *os.File) *browser {
+	b := &browser{
+		tty:      tty,
+		sections: section.All(),
+		codes:    map[string][]string{},
+		outputs:  map[string]*browserOutput{},
+	}
+	file := ""
+	for i, s := range b.sections {
+		if s.File != file {
+			file = s.File
+			b.sidebar = append(b.sidebar, sidebarRow{topic: s.Topic(), sec: -1})
+		}
+		b.sidebar = append(b.sidebar, sidebarRow{sec: i})
+	}
+	return b
+}
+
+// layout works out the size of the terminal and of each pane.
+func (b *browser) layout() error {
+	cols, rows, err := termSize(b.tty)
+	if err != nil {
+		return err
+	}
+	b.cols, b.rows = cols, rows
+	if w := b.codeWidth(); w != b.width {
+		b.width = w
+		b.codes = map[string][]string{}
+	}
+	return nil
+}
+
+func (b *browser) sidebarWidth() int {
+	w := b.cols / 3
+	if w > 32 {
+		w = 32
+	}
+	return w
+}
+
+func (b *browser) codeWidth() int {
+	return b.cols - b.sidebarWidth() - 2
+}
+
+// bodyRows is the height of the panes, between the title and the keys.
+func (b *browser) bodyRows() int {
+	return b.rows - 2
+}
+
+// code renders the code of a section with its explanation, one line per
+// screen line.
+func (b *browser) code(s section.Section) []string {
+	if lines, ok := b.codes[s.ID()]; ok {
+		return lines
+	}
+	ss, err := source(s)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	texts := []string{ss.slice(ss.fn.Pos(), ss.fn.End())}
+	for _, d := range ss.uses() {
+		texts = append(texts, ss.text(d))
+	}
+	var lines []string
+	for _, t := range texts {
+		for _, seg := range segments(t) {
+			for _, p := range paragraphs(seg.doc) {
+				if p.text != "" {
+					for _, l := range wrap(p.text, b.width) {
+						lines = append(lines, ansiDoc+l+ansiReset)
+					}
+				}
+				for _, l := range p.pre {
+					lines = append(lines, ansiDoc+truncate("  "+expandTabs(l), b.width)+ansiReset)
+				}
+			}
+			if seg.code != "" {
+				for _, l := range strings.Split(seg.code, "\n") {
+					lines = append(lines, ansiHighlight(truncate(expandTabs(l), b.width)))
+				}
+			}
+			lines = append(lines, "")
+		}
+	}
+	b.codes[s.ID()] = lines
+	return lines
+}
+
+// ansiHighlight colours a line of Go code for the terminal.
+func ansiHighlight(line string) string {
+	var b strings.Builder
+	classify(line, func(text, class string) {
+		if esc, ok := ansiClasses[class]; ok {
+			b.WriteString(esc + text + ansiReset)
+			return
+		}
+		b.WriteString(text)
+	})
+	return b.String()
+}
+
+// wrap breaks text into lines of at most width columns, between words.
+func wrap(text string, width int) []string {
+	var lines []string
+	line := ""
+	for _, w := range strings.Fields(text) {
+		if line != "" && utf8.RuneCountInString(line)+1+utf8.RuneCountInString(w) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += w
+	}
+	if line != "" {
+		lines = append(lines, truncate(line, width))
+	}
+	return lines
+}
+
+// truncate cuts s down to at most width columns.
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	n := 0
+	for i := range s {
+		if n == width {
+			return s[:i]
+		}
+		n++
+	}
+	return s
+}
+
+func expandTabs(s string) string {
+	return strings.Replace(s, "\t", "    ", -1)
+}
+
+// pad fills s with spaces to width columns, cutting it if it is wider.
+func pad(s string, width int) string {
+	s = truncate(s, width)
+	return s + strings.Repeat(" ", width-utf8.RuneCountInString(s))
+}
+
+// draw redraws the whole screen.
+func (b *browser) draw() {
+	var out strings.Builder
+	out.WriteString("\x1b[H")
+	title := " Go by Example"
+	if b.status != "" {
+		title += "  " + b.status
+	}
+	fmt.Fprintf(&out, "%s%s%s\x1b[K\r\n", ansiReverse, pad(title, b.cols), ansiReset)
+
+	sw, body := b.sidebarWidth(), b.bodyRows()
+	right := b.rightPane(body)
+
+	// Keep the selected section in view.
+	row := 0
+	for i, r := range b.sidebar {
+		if r.sec == b.sel {
+			row = i
+		}
+	}
+	if row < b.top {
+		b.top = row
+	}
+	if row >= b.top+body {
+		b.top = row - body + 1
+	}
+	if b.sel == 0 {
+		b.top = 0 // Show the first heading too
+	}
+
+	for i := 0; i < body; i++ {
+		left := ""
+		if j := b.top + i; j < len(b.sidebar) {
+			r := b.sidebar[j]
+			switch {
+			case r.sec < 0:
+				left = ansiBold + pad(r.topic, sw) + ansiReset
+			case r.sec == b.sel:
+				left = ansiReverse + pad(" "+b.sections[r.sec].Title, sw) + ansiReset
+			default:
+				left = pad(" "+b.sections[r.sec].Title, sw)
+			}
+		} else {
+			left = pad("", sw)
+		}
+		fmt.Fprintf(&out, "%s \x1b[2m│%s %s%s\x1b[K\r\n", left, ansiReset, right[i], ansiReset)
+	}
+	fmt.Fprintf(&out, "\x1b[2m%s%s\x1b[K", truncate(" "+browseKeys, b.cols), ansiReset)
+	b.tty.WriteString(out.String())
+}
+
+// rightPane returns the lines to show beside the sidebar: the code of the
+// selected section and, once it has been run, its output underneath.
+func (b *browser) rightPane(height int) []string {
+	s := b.sections[b.sel]
+	lines := make([]string, height)
+	if height < 3 {
+		return lines
+	}
+	codeRows := height
+	o := b.outputs[s.ID()]
+	if o != nil {
+		codeRows = height / 2
+		if b.full {
+			codeRows = 0
+		}
+	}
+
+	code := b.code(s)
+	if last := len(code) - codeRows; b.scroll > last {
+		b.scroll = last
+	}
+	if b.scroll < 0 {
+		b.scroll = 0
+	}
+	for i := 0; i < codeRows && b.scroll+i < len(code); i++ {
+		lines[i] = code[b.scroll+i]
+	}
+	if o == nil {
+		return lines
+	}
+
+	header := "── Output of go run . run " + s.ID() + " "
+	if o.running {
+		header += "(running) "
+	}
+	lines[codeRows] = "\x1b[2m" + pad(header, b.width) + ansiReset
+	text := strings.TrimSuffix(string(o.text), "\n")
+	var outLines []string
+	if text != "" {
+		outLines = strings.Split(text, "\n")
+	}
+	// The latest output is always in view.
+	n := height - codeRows - 1
+	if len(outLines) > n {
+		outLines = outLines[len(outLines)-n:]
+	}
+	for i, l := range outLines {
+		lines[codeRows+1+i] = truncate(expandTabs(l), b.width)
+	}
+	return lines
+}
+
+// run runs the selected section, sending its output to updates as it is
+// written so the screen can be redrawn.
+func (b *browser) run(updates chan<- func()) {
+	if b.running {
+		b.status = "wait for the running section to finish"
+		return
+	}
+	s := b.sections[b.sel]
+	r, w, err := os.Pipe()
+	if err != nil {
+		b.status = err.Error()
+		return
+	}
+	o := &browserOutput{running: true}
+	b.outputs[s.ID()] = o
+	b.running = true
+	b.status = "running " + s.Title
+
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := r.Read(buf)
+			if n > 0 {
+				chunk := string(buf[:n])
+				updates <- func() { o.text = append(o.text, chunk...) }
+			}
+			if err != nil {
+				break
+			}
+		}
+		r.Close()
+		updates <- func() {
+			o.running, b.running = false, false
+			b.status = ""
+		}
+	}()
+
+	os.Stdout = w
+	go func() {
+		// A section that panics would otherwise end the program with the
+		// terminal still in raw mode, so the panic is shown in its output.
+		defer func() {
+			os.Stdout = b.tty
+			if v := recover(); v != nil {
+				fmt.Fprintf(w, "panic: %v\n", v)
+			}
+			w.Close()
+		}()
+		s.Run()
+	}()
+}
+
+// key handles a key press, and reports whether to quit.
+func (b *browser) key(k string, updates chan<- func()) bool {
+	page := b.bodyRows() / 2
+	switch k {
+	case "q", "\x03":
+		return true
+	case "j", "\x1b[B", "\x1bOB", "\x0e":
+		b.selectSection(b.sel + 1)
+	case "k", "\x1b[A", "\x1bOA", "\x10":
+		b.selectSection(b.sel - 1)
+	case "g", "\x1b[H", "\x1b[1~":
+		b.selectSection(0)
+	case "G", "\x1b[F", "\x1b[4~":
+		b.selectSection(len(b.sections) - 1)
+	case "\r", "\n", "r":
+		b.run(updates)
+	case " ", "\x1b[6~", "\x04":
+		b.scroll += page
+	case "b", "\x1b[5~", "\x15":
+		b.scroll -= page
+	case "o":
+		b.full = !b.full
+	}
+	return false
+}
+
+// splitKeys splits what was read from the terminal into key presses, as
+// several can arrive at once over a slow connection. Escape sequences, like
+// "\x1b[A" for the up arrow, are a single key.
+func splitKeys(s string) []string {
+	var keys []string
+	for s != "" {
+		n := 1
+		if strings.HasPrefix(s, "\x1b[") || strings.HasPrefix(s, "\x1bO") {
+			n = 2
+			for n < len(s) {
+				c := s[n]
+				n++
+				if c >= 0x40 && c <= 0x7e {
+					break
+				}
+			}
+		} else if _, size := utf8.DecodeRuneInString(s); size > 1 {
+			n = size
+		}
+		keys = append(keys, s[:n])
+		s = s[n:]
+	}
+	return keys
+}
+
+func (b *browser) selectSection(i int) {
+	if i < 0 || i >= len(b.sections) || i == b.sel {
+		return
+	}
+	b.sel, b.scroll = i, 0
+}
+
+func browseCmd(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(args, " "))
+	}
+	tty := os.Stdout
+	if !isTerminal(os.Stdin) || !isTerminal(tty) {
+		return fmt.Errorf("browse needs a terminal")
+	}
+	restore, err := rawMode(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	b := newBrowser(tty)
+	if err := b.layout(); err != nil {
+		return err
+	}
+	// Use the alternate screen, so the terminal is left as it was.
+	tty.WriteString("\x1b[?1049h\x1b[?25l\x1b[2J")
+	defer tty.WriteString("\x1b[?25h\x1b[?1049l")
+
+	keys := make(chan string)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				close(keys)
+				return
+			}
+			for _, k := range splitKeys(string(buf[:n])) {
+				keys <- k
+			}
+		}
+	}()
+	resize := make(chan os.Signal, 1)
+	notifyResize(resize)
+	updates := make(chan func())
+
+	for {
+		b.draw()
+		select {
+		case k, ok := <-keys:
+			if !ok || b.key(k, updates) {
+				return nil
+			}
+		case <-resize:
+			if err := b.layout(); err != nil {
+				return err
+			}
+			tty.WriteString("\x1b[2J")
+		case update := <-updates:
+			update()
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/omussell/go-by-example/section"
+)
+
+// A section that panics shows the panic in its output, and the browser, and
+// the terminal it has in raw mode, carry on.
+func TestBrowserRunRecoversPanic(t *testing.T) {
+	stdout := os.Stdout
+	b := &browser{
+		tty: stdout,
+		sections: []section.Section{{File: "test/1-test.go", Title: "Panics", Run: func() {
+			fmt.Println("before")
+			panic("boom")
+		}}},
+		outputs: map[string]*browserOutput{},
+	}
+	updates := make(chan func())
+	b.run(updates)
+	for b.running {
+		(<-updates)()
+	}
+	if got, want := string(b.outputs[b.sections[0].ID()].text), "before\npanic: boom\n"; got != want {
+		t.Errorf("output is %q, want %q", got, want)
+	}
+	if os.Stdout != stdout {
+		t.Error("os.Stdout was left capturing the section")
+	}
+}
@@ -0,0 +1,371 @@
+// Package clock is where the examples get the time from, so that the runner
+// can pretend it is any time it likes.
+//
+// By default it is the real time, and the functions here behave exactly like
+// the ones in the time package. Set swaps in another clock, such as a
+// Virtual one that is frozen at a given time and where sleeps pass without
+// waiting, so that examples print the same thing every time they run.
+//
+// Virtual timers and tickers follow the rules time's have had since Go 1.23,
+// which go.mod requires, so the two clocks agree. In a module that says an
+// earlier version, time's timers keep a time that wasn't received in C
+// after Stop or Reset, while virtual ones would still drop it.
+package clock
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// A Clock tells the time and lets time pass.
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	After(d time.Duration) <-chan time.Time
+	NewTimer(d time.Duration) *Timer
+	AfterFunc(d time.Duration, f func()) *Timer
+	NewTicker(d time.Duration) *Ticker
+}
+
+// A Timer is a time.Timer from a Clock. It sends the time on C, unless it
+// was made by AfterFunc, once, when it fires.
+type Timer struct {
+	C     <-chan time.Time
+	stop  func() bool
+	reset func(d time.Duration) bool
+}
+
+// Stop stops the timer from firing. It returns false if it had already been
+// stopped, or had fired and its time been received from C. As with a
+// time.Timer since Go 1.23, a time that was sent but not received is
+// dropped, so nothing is left to receive from C afterwards.
+func (t *Timer) Stop() bool { return t.stop() }
+
+// Reset makes the timer fire after d instead, dropping any time that hasn't
+// been received, and returns what Stop would have.
+func (t *Timer) Reset(d time.Duration) bool { return t.reset(d) }
+
+// A Ticker is a time.Ticker from a Clock. It sends the time on C every
+// period, dropping ticks when whatever is receiving them falls behind.
+type Ticker struct {
+	C     <-chan time.Time
+	stop  func()
+	reset func(d time.Duration)
+}
+
+// Stop turns off the ticker, dropping any tick that hasn't been received. It
+// doesn't close C.
+func (t *Ticker) Stop() { t.stop() }
+
+// Reset stops the ticker and starts it again with a period of d.
+func (t *Ticker) Reset(d time.Duration) { t.reset(d) }
+
+// Playground is the time the examples are checked at, 23:00 UTC on Tuesday
+// the 10th of November 2009, the same as on the Go playground.
+var Playground = time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+
+// Real is the clock of the time package.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+func (realClock) NewTimer(d time.Duration) *Timer {
+	t := time.NewTimer(d)
+	return &Timer{C: t.C, stop: t.Stop, reset: t.Reset}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) *Timer {
+	t := time.AfterFunc(d, f)
+	return &Timer{stop: t.Stop, reset: t.Reset}
+}
+
+func (realClock) NewTicker(d time.Duration) *Ticker {
+	t := time.NewTicker(d)
+	return &Ticker{C: t.C, stop: t.Stop, reset: t.Reset}
+}
+
+// current is the clock in use. It is swapped atomically, as goroutines left
+// running by one example can still be asking it the time when the next is
+// run.
+var current atomic.Pointer[Clock]
+
+func init() {
+	c := Real
+	current.Store(&c)
+}
+
+func get() Clock { return *current.Load() }
+
+// Set makes c the clock used by the functions in this package, and returns a
+// function that puts back the one before. It is safe to call while other
+// goroutines are using the clock, though anything already waiting on the
+// clock before keeps waiting on that one.
+func Set(c Clock) (restore func()) {
+	prev := current.Swap(&c)
+	return func() { current.Store(prev) }
+}
+
+// Now returns the current time.
+func Now() time.Time { return get().Now() }
+
+// Sleep pauses the current goroutine for at least d.
+func Sleep(d time.Duration) { get().Sleep(d) }
+
+// After waits for d to pass and then sends the current time on the channel.
+func After(d time.Duration) <-chan time.Time { return get().After(d) }
+
+// Since returns the time passed since t.
+func Since(t time.Time) time.Duration { return get().Now().Sub(t) }
+
+// NewTimer returns a Timer that sends the current time on its channel after
+// at least d.
+func NewTimer(d time.Duration) *Timer { return get().NewTimer(d) }
+
+// AfterFunc calls f in its own goroutine after d, and returns a Timer that
+// can stop it.
+func AfterFunc(d time.Duration, f func()) *Timer { return get().AfterFunc(d, f) }
+
+// NewTicker returns a Ticker that sends the current time on its channel every
+// d. d must be greater than zero.
+func NewTicker(d time.Duration) *Ticker { return get().NewTicker(d) }
+
+// Tick is NewTicker(d).C, for when the ticker never needs stopping. As with
+// time.Tick, it can never be stopped, so it runs until the program exits,
+// and with a Virtual clock keeps a goroutine moving the time on until then.
+// It returns nil if d <= 0.
+func Tick(d time.Duration) <-chan time.Time {
+	if d <= 0 {
+		return nil
+	}
+	return get().NewTicker(d).C
+}
+
+// settle is how long a Virtual clock lets the program get on with whatever
+// it can before moving the time on.
+const settle = 10 * time.Millisecond
+
+// A Virtual clock only moves on when it is advanced. Its zero value is not
+// usable, create one with NewVirtual.
+//
+// When goroutines are waiting for it, it moves on by itself, straight to the
+// earliest time one of them is waiting for, once the program has had a
+// moment to run anything not waiting for the clock. So a second's sleep
+// takes a few milliseconds, and goroutines still get to run while others
+// sleep, as they would with the real clock. A ticker that is never stopped
+// keeps it moving on for as long as the program runs.
+//
+// That moment is settle of real time, as there is no telling from here
+// whether the other goroutines are blocked or just haven't been scheduled. A
+// goroutine woken by the clock that takes longer than that to get back to it,
+// say to Reset a timeout on a heavily loaded machine, finds the clock has
+// already moved on to the next thing waiting, as if it had been slow in real
+// time. The examples only do a little work between waits, so this takes a
+// machine too busy to run a woken goroutine within settle.
+type Virtual struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiting []*waiter // In the order they are due
+	moving  bool      // The goroutine moving the time on is running
+}
+
+// A waiter is a sleep, timer or ticker waiting for the virtual time.
+type waiter struct {
+	at     time.Time
+	period time.Duration // How often a ticker ticks, or 0 for the others
+	fire   func(now time.Time)
+}
+
+// NewVirtual returns a Virtual clock set to now.
+func NewVirtual(now time.Time) *Virtual {
+	return &Virtual{now: now}
+}
+
+// Now returns the virtual time.
+func (v *Virtual) Now() time.Time {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.now
+}
+
+// Sleep waits until the virtual time is d later than now.
+func (v *Virtual) Sleep(d time.Duration) {
+	<-v.After(d)
+}
+
+// After sends the virtual time on the channel once it is d later than now.
+func (v *Virtual) After(d time.Duration) <-chan time.Time {
+	return v.NewTimer(d).C
+}
+
+// NewTimer returns a Timer that fires once the virtual time is d later than
+// now.
+func (v *Virtual) NewTimer(d time.Duration) *Timer {
+	c := make(chan time.Time, 1)
+	return v.timer(d, func(now time.Time) { send(c, now) }, c)
+}
+
+// AfterFunc calls f in its own goroutine once the virtual time is d later
+// than now.
+func (v *Virtual) AfterFunc(d time.Duration, f func()) *Timer {
+	return v.timer(d, func(time.Time) { go f() }, nil)
+}
+
+func (v *Virtual) timer(d time.Duration, fire func(time.Time), c chan time.Time) *Timer {
+	w := &waiter{fire: fire}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.schedule(w, d)
+	return &Timer{
+		C: c,
+		stop: func() bool {
+			v.mu.Lock()
+			defer v.mu.Unlock()
+			return v.remove(w) || drain(c)
+		},
+		reset: func(d time.Duration) bool {
+			v.mu.Lock()
+			defer v.mu.Unlock()
+			waiting := v.remove(w) || drain(c)
+			v.schedule(w, d)
+			return waiting
+		},
+	}
+}
+
+// NewTicker returns a Ticker that ticks each time the virtual time moves on
+// by d.
+func (v *Virtual) NewTicker(d time.Duration) *Ticker {
+	if d <= 0 {
+		panic("clock: non-positive interval for NewTicker")
+	}
+	c := make(chan time.Time, 1)
+	w := &waiter{period: d, fire: func(now time.Time) { send(c, now) }}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.schedule(w, d)
+	return &Ticker{
+		C: c,
+		stop: func() {
+			v.mu.Lock()
+			defer v.mu.Unlock()
+			v.remove(w)
+			drain(c)
+		},
+		reset: func(d time.Duration) {
+			if d <= 0 {
+				panic("clock: non-positive interval for Ticker.Reset")
+			}
+			v.mu.Lock()
+			defer v.mu.Unlock()
+			v.remove(w)
+			drain(c)
+			w.period = d
+			v.schedule(w, d)
+		},
+	}
+}
+
+// send sends now on c unless it is full, as time's timers and tickers do.
+func send(c chan time.Time, now time.Time) {
+	select {
+	case c <- now:
+	default:
+	}
+}
+
+// drain drops the time waiting on c, if there is one, and reports whether
+// there was. A fired timer whose time hasn't been received counts as not
+// having fired yet, as with time's timers since Go 1.23.
+func drain(c chan time.Time) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+// Advance moves the virtual time on by d, firing anything due by then in
+// turn, each at the time it was due.
+func (v *Virtual) Advance(d time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	end := v.now.Add(d)
+	for len(v.waiting) > 0 && !v.waiting[0].at.After(end) {
+		v.fireNext()
+	}
+	v.now = end
+}
+
+// schedule makes w fire d after now, firing it straight away if d <= 0. v.mu
+// must be held.
+func (v *Virtual) schedule(w *waiter, d time.Duration) {
+	if d <= 0 {
+		w.fire(v.now)
+		return
+	}
+	w.at = v.now.Add(d)
+	v.insert(w)
+	if !v.moving {
+		v.moving = true
+		go v.move()
+	}
+}
+
+// insert adds w to the waiters, after any due at the same time. v.mu must be
+// held.
+func (v *Virtual) insert(w *waiter) {
+	i := sort.Search(len(v.waiting), func(i int) bool { return v.waiting[i].at.After(w.at) })
+	v.waiting = append(v.waiting, nil)
+	copy(v.waiting[i+1:], v.waiting[i:])
+	v.waiting[i] = w
+}
+
+// remove takes w out of the waiters, and reports whether it was there. v.mu
+// must be held.
+func (v *Virtual) remove(w *waiter) bool {
+	for i, x := range v.waiting {
+		if x == w {
+			v.waiting = append(v.waiting[:i], v.waiting[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// fireNext moves the time on to when the first waiter is due, if it isn't
+// already, and fires it, scheduling a ticker's next tick. v.mu must be held.
+func (v *Virtual) fireNext() {
+	w := v.waiting[0]
+	v.waiting = v.waiting[1:]
+	if w.at.After(v.now) {
+		v.now = w.at
+	}
+	if w.period > 0 {
+		w.at = w.at.Add(w.period)
+		v.insert(w)
+	}
+	w.fire(v.now)
+}
+
+// move moves the time on to each time that is waited for in turn, until
+// nothing is waiting.
+func (v *Virtual) move() {
+	for {
+		time.Sleep(settle)
+		v.mu.Lock()
+		if len(v.waiting) == 0 {
+			v.moving = false
+			v.mu.Unlock()
+			return
+		}
+		v.fireNext()
+		v.mu.Unlock()
+	}
+}
@@ -0,0 +1,268 @@
+package clock
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+var start = Playground
+
+// manual returns a Virtual clock that only moves on when it is advanced, so
+// tests can say exactly when things happen.
+func manual() *Virtual {
+	v := NewVirtual(start)
+	v.moving = true // As if it were already being moved on, so it never is
+	return v
+}
+
+// received returns the time waiting on c, and whether there was one.
+func received(c <-chan time.Time) (time.Time, bool) {
+	select {
+	case t := <-c:
+		return t, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	v := manual()
+	var fired []time.Duration
+	for _, d := range []time.Duration{3 * time.Second, time.Second, 2 * time.Second, time.Second} {
+		v.timer(d, func(now time.Time) { fired = append(fired, now.Sub(start)) }, nil)
+	}
+
+	v.Advance(1500 * time.Millisecond)
+	if got := v.Now().Sub(start); got != 1500*time.Millisecond {
+		t.Errorf("after Advance(1.5s), Now is %v after the start", got)
+	}
+	v.Advance(5 * time.Second)
+	if got := v.Now().Sub(start); got != 6500*time.Millisecond {
+		t.Errorf("after Advance(5s), Now is %v after the start", got)
+	}
+	want := []time.Duration{time.Second, time.Second, 2 * time.Second, 3 * time.Second}
+	if len(fired) != len(want) {
+		t.Fatalf("fired at %v, want %v", fired, want)
+	}
+	for i := range want {
+		if fired[i] != want[i] {
+			t.Fatalf("fired at %v, want %v", fired, want)
+		}
+	}
+}
+
+// Left to move on by itself, the clock goes straight to each time that is
+// waited for, in order.
+func TestSleep(t *testing.T) {
+	v := NewVirtual(start)
+	c3, c1, c2 := v.After(3*time.Second), v.After(time.Second), v.After(2*time.Second)
+	var order []time.Duration
+	for len(order) < 3 {
+		select {
+		case now := <-c1:
+			order = append(order, now.Sub(start))
+		case now := <-c2:
+			order = append(order, now.Sub(start))
+		case now := <-c3:
+			order = append(order, now.Sub(start))
+		}
+	}
+	if order[0] != time.Second || order[1] != 2*time.Second || order[2] != 3*time.Second {
+		t.Errorf("received %v, want [1s 2s 3s]", order)
+	}
+
+	v.Sleep(time.Second)
+	if got := v.Now().Sub(start); got != 4*time.Second {
+		t.Errorf("after sleeping a second more, Now is %v after the start, want 4s", got)
+	}
+	v.Sleep(0)
+	v.Sleep(-time.Second)
+	if got := v.Now().Sub(start); got != 4*time.Second {
+		t.Errorf("sleeping for no time moved Now to %v after the start", got)
+	}
+}
+
+// The clock only waits settle for the program before moving on, whether or
+// not the program has finished what it was doing.
+func TestVirtualDoesNotWaitForSlowGoroutines(t *testing.T) {
+	v := NewVirtual(start)
+	timeout := v.NewTimer(time.Second)
+	time.Sleep(20 * settle) // Real time, which the clock can't see
+	if got := v.Now().Sub(start); got != time.Second {
+		t.Errorf("after %v of real time, Now is %v after the start, want 1s", 20*settle, got)
+	}
+	if !timeout.Reset(time.Second) {
+		t.Error("Reset returned false, so the timer hadn't fired")
+	}
+	if now := <-timeout.C; now.Sub(start) != 2*time.Second {
+		t.Errorf("reset timer fired %v after the start, want 2s", now.Sub(start))
+	}
+}
+
+func TestTimerStop(t *testing.T) {
+	v := manual()
+
+	tm := v.NewTimer(time.Second)
+	if !tm.Stop() {
+		t.Error("Stop of a waiting timer returned false")
+	}
+	if tm.Stop() {
+		t.Error("second Stop returned true")
+	}
+	v.Advance(2 * time.Second)
+	if _, ok := received(tm.C); ok {
+		t.Error("stopped timer fired")
+	}
+
+	tm = v.NewTimer(time.Second)
+	v.Advance(time.Second)
+	if !tm.Stop() {
+		t.Error("Stop of a fired timer whose time wasn't received returned false")
+	}
+	if _, ok := received(tm.C); ok {
+		t.Error("Stop left the time in C")
+	}
+
+	tm = v.NewTimer(time.Second)
+	v.Advance(time.Second)
+	<-tm.C
+	if tm.Stop() {
+		t.Error("Stop of a fired timer whose time was received returned true")
+	}
+
+	called := false
+	tm = v.AfterFunc(time.Second, func() { called = true })
+	if !tm.Stop() {
+		t.Error("Stop of a waiting AfterFunc returned false")
+	}
+	v.Advance(2 * time.Second)
+	if called {
+		t.Error("stopped AfterFunc was called")
+	}
+}
+
+func TestTimerReset(t *testing.T) {
+	v := manual()
+
+	tm := v.NewTimer(time.Second)
+	if !tm.Reset(2 * time.Second) {
+		t.Error("Reset of a waiting timer returned false")
+	}
+	v.Advance(time.Second)
+	if _, ok := received(tm.C); ok {
+		t.Error("reset timer fired at the time it was first due")
+	}
+	v.Advance(time.Second)
+	if now, ok := received(tm.C); !ok || now.Sub(start) != 2*time.Second {
+		t.Errorf("reset timer fired at %v after the start, %v, want 2s", now.Sub(start), ok)
+	}
+
+	// Fired, but nothing received the time, so it is dropped.
+	tm = v.NewTimer(time.Second)
+	v.Advance(time.Second)
+	if !tm.Reset(time.Second) {
+		t.Error("Reset of a fired timer whose time wasn't received returned false")
+	}
+	if _, ok := received(tm.C); ok {
+		t.Error("Reset left the old time in C")
+	}
+	v.Advance(time.Second)
+	if now, ok := received(tm.C); !ok || now.Sub(start) != 4*time.Second {
+		t.Errorf("reset timer fired at %v after the start, %v, want 4s", now.Sub(start), ok)
+	}
+	if tm.Reset(time.Second) {
+		t.Error("Reset of a fired timer whose time was received returned true")
+	}
+	if !tm.Reset(0) {
+		t.Error("Reset of a timer reset a moment ago returned false")
+	}
+	if now, ok := received(tm.C); !ok || now.Sub(start) != 4*time.Second {
+		t.Errorf("Reset(0) fired at %v after the start, %v, want straight away", now.Sub(start), ok)
+	}
+}
+
+// A slow receiver gets the first tick it missed, and the rest are dropped.
+func TestTickerDropsTicks(t *testing.T) {
+	v := manual()
+	tk := v.NewTicker(time.Second)
+	v.Advance(3500 * time.Millisecond)
+	if now, ok := received(tk.C); !ok || now.Sub(start) != time.Second {
+		t.Errorf("first tick at %v after the start, %v, want 1s", now.Sub(start), ok)
+	}
+	if now, ok := received(tk.C); ok {
+		t.Errorf("got a second tick at %v, want the missed ones dropped", now.Sub(start))
+	}
+	v.Advance(500 * time.Millisecond)
+	if now, ok := received(tk.C); !ok || now.Sub(start) != 4*time.Second {
+		t.Errorf("next tick at %v after the start, %v, want 4s", now.Sub(start), ok)
+	}
+
+	tk.Reset(2 * time.Second)
+	v.Advance(time.Second)
+	if _, ok := received(tk.C); ok {
+		t.Error("reset ticker ticked at its old period")
+	}
+	v.Advance(time.Second)
+	if now, ok := received(tk.C); !ok || now.Sub(start) != 6*time.Second {
+		t.Errorf("reset ticker ticked at %v after the start, %v, want 6s", now.Sub(start), ok)
+	}
+
+	v.Advance(2 * time.Second)
+	tk.Stop()
+	if _, ok := received(tk.C); ok {
+		t.Error("Stop left a tick in C")
+	}
+	v.Advance(10 * time.Second)
+	if _, ok := received(tk.C); ok {
+		t.Error("stopped ticker ticked")
+	}
+}
+
+func TestSet(t *testing.T) {
+	if d := time.Since(Now()); d < -time.Minute || d > time.Minute {
+		t.Fatalf("the default clock is %v out from the real time", d)
+	}
+
+	restore := Set(NewVirtual(start))
+	if got := Now(); !got.Equal(start) {
+		t.Errorf("Now is %v, want %v", got, start)
+	}
+	later := start.Add(time.Hour)
+	restoreLater := Set(NewVirtual(later))
+	if got := Now(); !got.Equal(later) {
+		t.Errorf("Now is %v, want %v", got, later)
+	}
+	restoreLater()
+	if got := Now(); !got.Equal(start) {
+		t.Errorf("after restoring, Now is %v, want %v", got, start)
+	}
+	restore()
+	if d := time.Since(Now()); d < -time.Minute || d > time.Minute {
+		t.Errorf("after restoring the real clock, Now is %v out from the real time", d)
+	}
+}
+
+// Goroutines left running by one example can ask the time while the next
+// sets its own clock, which go test -race checks is safe.
+func TestSetWhileInUse(t *testing.T) {
+	stop := make(chan bool)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				Now()
+			}
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		Set(NewVirtual(start))()
+	}
+	close(stop)
+	wg.Wait()
+}
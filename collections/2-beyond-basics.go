@@ -3,13 +3,25 @@
 // Ranges
 // Functions
 // Multiple Return Values
-package main
+package collections
 
-import "fmt"
+import (
+	"fmt"
 
-func beyondBasics() {
+	"github.com/omussell/go-by-example/section"
+)
 
-	// Slices
+// Sections are the examples in this file, in reading order.
+var Sections = []section.Section{
+	{Title: "Slices", Run: Slices},
+	{Title: "Maps", Run: Maps},
+	{Title: "Ranges", Run: Ranges},
+	{Title: "Functions", Run: Functions},
+	{Title: "Multiple Return Values", Run: MultipleReturnValues},
+}
+
+// Slices
+func Slices() {
 	a := make([]string, 3)
 	fmt.Println("emp:", a) // Prints emp: [  ]
 	/*
@@ -42,7 +54,7 @@ func beyondBasics() {
 	*/
 
 	c = a[:5]
-	fmt.Println("sl2:", c) // Prints [a b c d e]. Up to, but excluding 5.
+	fmt.Println("sl2:", c) // Prints sl2: [a b c d e]. Up to, but excluding 5.
 
 	c = a[2:]
 	fmt.Println("sl3:", c) // Prints sl3: [c d e f]. Up from and including 2.
@@ -62,10 +74,11 @@ func beyondBasics() {
 	/*
 	   Slices can create multi-dimensional data structures. The length of the inner slices can vary while arrays cannot do this.
 	*/
+}
 
-	// Maps
-	// same as dict
-
+// Maps
+// same as dict
+func Maps() {
 	g := make(map[string]int) // Create empty map: make(map[key-type]value-type).
 
 	g["k1"] = 7
@@ -74,7 +87,7 @@ func beyondBasics() {
 	fmt.Println("map:", g) // Prints map: map[k1:7 k2:13]. Set key/value pairs using name[key] = value.
 
 	h1 := g["k1"]
-	fmt.Println("h1: ", h1) // prints v1:  7
+	fmt.Println("h1: ", h1) // prints h1:  7
 
 	fmt.Println("len:", len(g)) // prints len: 2
 
@@ -86,9 +99,10 @@ func beyondBasics() {
 
 	i := map[string]int{"foo": 1, "bar": 2}
 	fmt.Println("map:", i) // Prints map: map[bar:2 foo:1]. Declare and initialise a new map in one line.
+}
 
-	// Ranges
-
+// Ranges
+func Ranges() {
 	nums := []int{2, 3, 4}
 	sum := 0
 	for _, num := range nums { // ignore index with _
@@ -105,24 +119,18 @@ func beyondBasics() {
 	kvs := map[string]string{"a": "apple", "b": "banana"}
 	for k, v := range kvs {
 		fmt.Printf("%s -> %s\n", k, v)
-	} // Prints a -> apple \n b -> banana. range on map iterates over key/value pairs.
+	} // Prints, in any order: a -> apple \n b -> banana. range on map iterates over key/value pairs.
 
 	for k := range kvs {
 		fmt.Println("key:", k)
-	} // Prints key: a \n key: b. range can iterate over keys of map.
+	} // Prints, in any order: key: a \n key: b. range can iterate over keys of map.
 
 	for i, c := range "go" {
 		fmt.Println(i, c)
-	} // Prints 0 103 \n 1 111. range on strings iterates over Unicode. First value is the byte index of the rune. Second is the rune itself.
-
-	// Functions
-	functionTest()
-
-	// Multiple Return Values
-	multiReturnValues()
-
+	} // Prints 0 103 \n 1 111. range on strings iterates over Unicode. First value is the byte index of the rune. Second is the rune itself. Strings and Runes in data/7-data-manip.go explains more.
 }
 
+// Functions
 func plus(a int, b int) int {
 	return a + b
 }
@@ -131,7 +139,7 @@ func plusPlus(a, b, c int) int {
 	return a + b + c
 }
 
-func functionTest() {
+func Functions() {
 	res := plus(1, 2)
 	fmt.Println("1+2 =", res) // prints 1+2 = 3
 
@@ -139,15 +147,16 @@ func functionTest() {
 	fmt.Println("1+2+3 =", res) // prints 1+2+3 = 6
 }
 
+// Multiple Return Values
 func vals() (int, int) {
 	return 3, 7
 }
 
-func multiReturnValues() {
+func MultipleReturnValues() {
 	a, b := vals()
 	fmt.Println(a) // prints 3
 	fmt.Println(b) // prints 7
 
 	_, c := vals()
-	fmt.Println(c)
+	fmt.Println(c) // prints 7. Use the blank identifier _ to only take some of the returned values
 }
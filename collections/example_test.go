@@ -0,0 +1,68 @@
+// Code generated by "go run . examples -w" from the Prints comments. DO NOT EDIT.
+
+package collections_test
+
+import (
+	"github.com/omussell/go-by-example/clock"
+	"github.com/omussell/go-by-example/collections"
+)
+
+func ExampleSlices() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	collections.Slices()
+	// Output:
+	// emp: [  ]
+	// set: [a b c]
+	// get: c
+	// len: 3
+	// apd: [a b c d e f]
+	// cpy: [a b c d e f]
+	// sl1: [c d e]
+	// sl2: [a b c d e]
+	// sl3: [c d e f]
+	// dcl: [g h i]
+	// 2d:  [[0] [1 2] [2 3 4]]
+}
+
+func ExampleMaps() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	collections.Maps()
+	// Output:
+	// map: map[k1:7 k2:13]
+	// h1:  7
+	// len: 2
+	// map: map[k1:7]
+	// prs: false
+	// map: map[bar:2 foo:1]
+}
+
+func ExampleRanges() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	collections.Ranges()
+	// Unordered output:
+	// sum: 9
+	// index: 1
+	// a -> apple
+	// b -> banana
+	// key: a
+	// key: b
+	// 0 103
+	// 1 111
+}
+
+func ExampleFunctions() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	collections.Functions()
+	// Output:
+	// 1+2 = 3
+	// 1+2+3 = 6
+}
+
+func ExampleMultipleReturnValues() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	collections.MultipleReturnValues()
+	// Output:
+	// 3
+	// 7
+	// 7
+}
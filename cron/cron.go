@@ -0,0 +1,319 @@
+// Package cron runs jobs on schedules written as cron expressions, like
+// "*/15 9-17 * * MON-FRI", for jobs that need to run at set times rather
+// than every so often.
+//
+// It tells the time with the clock package, so schedules can be run on a
+// virtual clock, where a day of them passes in moments.
+package cron
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omussell/go-by-example/clock"
+)
+
+// A Schedule is a parsed cron expression: the minutes, hours, days of the
+// month, months and days of the week it fires on, in a location.
+type Schedule struct {
+	minute, hour, dom, month, dow bits
+	domStar, dowStar              bool // The field was *, so only the other one picks days
+	loc                           *time.Location
+	expr                          string
+}
+
+// bits is a set of the values a field allows, one bit for each.
+type bits uint64
+
+func (b bits) has(n int) bool { return b&(1<<uint(n)) != 0 }
+
+// A field is one of the five fields of an expression, and the values it can
+// have.
+type field struct {
+	name     string
+	min, max int
+	names    []string // Names for values from min, like JAN for 1
+}
+
+var fields = []field{
+	{"minute", 0, 59, nil},
+	{"hour", 0, 23, nil},
+	{"day of month", 1, 31, nil},
+	{"month", 1, 12, strings.Fields("JAN FEB MAR APR MAY JUN JUL AUG SEP OCT NOV DEC")},
+	{"day of week", 0, 7, strings.Fields("SUN MON TUE WED THU FRI SAT")}, // 7 is Sunday too
+}
+
+// macros are the shorthands for common schedules.
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a cron expression in the Local time zone. See
+// ParseInLocation.
+func Parse(expr string) (*Schedule, error) {
+	return ParseInLocation(expr, time.Local)
+}
+
+// ParseInLocation parses a cron expression, whose times are in loc. An
+// expression has five fields, separated by spaces:
+//
+//	minute        0-59
+//	hour          0-23
+//	day of month  1-31
+//	month         1-12 or JAN-DEC
+//	day of week   0-7 or SUN-SAT, where 0 and 7 are both Sunday
+//
+// Each field is * for any value, or a comma separated list of values and
+// ranges like 1-5, any of which can be followed by a step, like */15 for
+// every fifteenth value or 10-50/20 for 10, 30 and 50. A single value with a
+// step, like 5/20, runs to the end of the range.
+//
+// When both day fields are restricted, a day matching either is enough, so
+// "0 0 1 * MON" is the 1st of the month and every Monday, as in other crons.
+// The shorthands @yearly, @monthly, @weekly, @daily and @hourly are also
+// understood.
+func ParseInLocation(expr string, loc *time.Location) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if m, ok := macros[strings.ToLower(spec)]; ok {
+		spec = m
+	}
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, fmt.Errorf("cron: %q has %d fields, not 5", expr, len(parts))
+	}
+	s := &Schedule{loc: loc, expr: expr}
+	sets := []*bits{&s.minute, &s.hour, &s.dom, &s.month, &s.dow}
+	for i, part := range parts {
+		b, err := fields[i].parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("cron: %q: %v", expr, err)
+		}
+		*sets[i] = b
+	}
+	if s.dow.has(7) {
+		s.dow |= 1 // Sunday is 0 from here on
+	}
+	s.domStar, s.dowStar = parts[2] == "*", parts[4] == "*"
+	return s, nil
+}
+
+// parse parses one field of an expression.
+func (f field) parse(s string) (bits, error) {
+	var b bits
+	for _, item := range strings.Split(s, ",") {
+		lo, hi, step := f.min, f.max, 1
+		rng := item
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			n, err := strconv.Atoi(item[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%s field: bad step in %q", f.name, item)
+			}
+			rng, step = item[:i], n
+		}
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			i := strings.IndexByte(rng, '-')
+			var err error
+			if lo, err = f.value(rng[:i]); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(rng[i+1:]); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("%s field: range %q goes backwards", f.name, rng)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rng); err != nil {
+				return 0, err
+			}
+			if step == 1 {
+				hi = lo // A single value, unless it has a step
+			}
+		}
+		for n := lo; n <= hi; n += step {
+			b |= 1 << uint(n)
+		}
+	}
+	return b, nil
+}
+
+// value parses a number or name in a field.
+func (f field) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s field: %q is not a number", f.name, s)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%s field: %d is not from %d to %d", f.name, n, f.min, f.max)
+	}
+	return n, nil
+}
+
+// String returns the expression the schedule was parsed from.
+func (s *Schedule) String() string { return s.expr }
+
+// Next returns the first time after t that the schedule fires, in the
+// schedule's location. It returns the zero Time if there isn't one in the
+// next five years, as for "0 0 30 2 *", the 30th of February.
+//
+// Daylight saving time changes are handled as most crons do. A time that is
+// skipped when the clocks go forward doesn't happen, so a job at 02:30 doesn't
+// run that night. When the clocks go back and an hour happens twice, a job
+// for a particular hour runs the first time only, while one for every hour
+// runs in both.
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.In(s.loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		y, m, d := t.Date()
+		switch {
+		case !s.month.has(int(m)):
+			t = later(t, time.Date(y, m+1, 1, 0, 0, 0, 0, s.loc))
+		case !s.dayMatches(t):
+			t = later(t, time.Date(y, m, d+1, 0, 0, 0, 0, s.loc))
+		case !s.hour.has(t.Hour()):
+			t = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute)
+		case !s.minute.has(t.Minute()), s.hour != allHours && repeated(t):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+const allHours bits = 1<<24 - 1
+
+// dayMatches reports whether the day fields allow t's day.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom, dow := s.dom.has(t.Day()), s.dow.has(int(t.Weekday()))
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// later returns next, unless time.Date normalised a wall time that doesn't
+// exist, like midnight on a day the clocks go forward at midnight, to a time
+// that isn't after t, when it returns the hour after.
+func later(t, next time.Time) time.Time {
+	for !next.After(t) {
+		next = next.Add(time.Hour)
+	}
+	return next
+}
+
+// repeated reports whether t's wall clock time already happened earlier the
+// same day, because the clocks went back.
+func repeated(t time.Time) bool {
+	for _, back := range []time.Duration{30 * time.Minute, time.Hour, 2 * time.Hour} {
+		e := t.Add(-back)
+		if e.Day() == t.Day() && e.Hour() == t.Hour() && e.Minute() == t.Minute() {
+			return true
+		}
+	}
+	return false
+}
+
+// A Scheduler runs jobs on their schedules. The zero value is ready to use:
+// add jobs with Add, and then call Run.
+type Scheduler struct {
+	// Skipped, if it is set, is called when a job is due but its last run is
+	// still going, so it isn't run again.
+	Skipped func(name string, at time.Time)
+
+	mu   sync.Mutex
+	jobs []job
+}
+
+type job struct {
+	name     string
+	schedule *Schedule
+	run      func(ctx context.Context, at time.Time)
+}
+
+// Add adds a job that calls run each time schedule fires, with the time it
+// was due. It must be called before Run.
+func (s *Scheduler) Add(name string, schedule *Schedule, run func(ctx context.Context, at time.Time)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, job{name, schedule, run})
+}
+
+// Run runs the jobs until ctx is cancelled, each in its own goroutine, and
+// never more than one run of a job at once. When ctx is cancelled, it waits
+// for the jobs that are running to return, which they should do promptly as
+// the ctx they are given is cancelled too, and returns ctx.Err(). It runs
+// until then even when there are no jobs, or none will fire again, so it
+// always returns ctx.Err().
+func (s *Scheduler) Run(ctx context.Context) error {
+	s.mu.Lock()
+	jobs := append([]job(nil), s.jobs...)
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, j := range jobs {
+		wg.Add(1)
+		go func(j job) {
+			defer wg.Done()
+			s.loop(ctx, j)
+		}(j)
+	}
+	<-ctx.Done()
+	wg.Wait()
+	return ctx.Err()
+}
+
+// loop runs j each time it is due, until ctx is cancelled.
+func (s *Scheduler) loop(ctx context.Context, j job) {
+	var running sync.WaitGroup
+	defer running.Wait()
+	busy := make(chan struct{}, 1) // Holds a value while j is running
+	for {
+		now := clock.Now()
+		next := j.schedule.Next(now)
+		if next.IsZero() {
+			return
+		}
+		timer := clock.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		select {
+		case busy <- struct{}{}:
+		default:
+			if s.Skipped != nil {
+				s.Skipped(j.name, next)
+			}
+			continue
+		}
+		running.Add(1)
+		go func(at time.Time) {
+			defer running.Done()
+			defer func() { <-busy }()
+			j.run(ctx, at)
+		}(next)
+	}
+}
@@ -0,0 +1,213 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/omussell/go-by-example/clock"
+)
+
+func TestNext(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(s string) time.Time {
+		t.Helper()
+		tm, err := time.ParseInLocation("2006-01-02 15:04 MST", s, ny)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+	// New York's clocks went forward at 02:00 on 2021-03-14, and back at
+	// 02:00 on 2021-11-07.
+	tests := []struct {
+		expr  string
+		after string
+		want  []string // The next few times it fires
+	}{
+		{"* * * * *", "2021-06-01 12:00 EDT", []string{"2021-06-01 12:01 EDT", "2021-06-01 12:02 EDT"}},
+		{"*/15 * * * *", "2021-06-01 12:07 EDT", []string{"2021-06-01 12:15 EDT", "2021-06-01 12:30 EDT", "2021-06-01 12:45 EDT", "2021-06-01 13:00 EDT"}},
+		{"0 9-17/4 * * *", "2021-06-01 12:00 EDT", []string{"2021-06-01 13:00 EDT", "2021-06-01 17:00 EDT", "2021-06-02 09:00 EDT"}},
+		{"30 8,12 * * MON-FRI", "2021-06-04 12:30 EDT", []string{"2021-06-07 08:30 EDT", "2021-06-07 12:30 EDT"}},
+		{"0 0 * * 7", "2021-06-01 00:00 EDT", []string{"2021-06-06 00:00 EDT"}},
+		{"5/20 0 1 1 *", "2021-06-01 00:00 EDT", []string{"2022-01-01 00:05 EST", "2022-01-01 00:25 EST", "2022-01-01 00:45 EST"}},
+		{"0 0 1 * MON", "2021-06-01 00:00 EDT", []string{"2021-06-07 00:00 EDT", "2021-06-14 00:00 EDT", "2021-06-21 00:00 EDT", "2021-06-28 00:00 EDT", "2021-07-01 00:00 EDT"}},
+		{"0 12 31 * *", "2021-06-01 00:00 EDT", []string{"2021-07-31 12:00 EDT", "2021-08-31 12:00 EDT", "2021-10-31 12:00 EDT"}},
+		{"0 0 29 FEB *", "2021-06-01 00:00 EDT", []string{"2024-02-29 00:00 EST"}},
+		{"0 0 30 2 *", "2021-06-01 00:00 EDT", []string{}},
+		{"@weekly", "2021-06-01 00:00 EDT", []string{"2021-06-06 00:00 EDT"}},
+		{"@hourly", "2021-06-01 23:30 EDT", []string{"2021-06-02 00:00 EDT"}},
+		{"30 2 * * *", "2021-03-13 12:00 EST", []string{"2021-03-15 02:30 EDT"}},                                                                           // 02:30 didn't happen on the 14th
+		{"*/30 * * * *", "2021-03-14 01:00 EST", []string{"2021-03-14 01:30 EST", "2021-03-14 03:00 EDT"}},                                                 // Nor did 02:00
+		{"30 1 * * *", "2021-11-06 12:00 EDT", []string{"2021-11-07 01:30 EDT", "2021-11-08 01:30 EST"}},                                                   // 01:30 happened twice on the 7th
+		{"30 * * * *", "2021-11-07 00:00 EDT", []string{"2021-11-07 00:30 EDT", "2021-11-07 01:30 EDT", "2021-11-07 01:30 EST", "2021-11-07 02:30 EST"}},   // Every hour runs in both
+		{"0 0 13 * FRI", "2021-06-01 00:00 EDT", []string{"2021-06-04 00:00 EDT", "2021-06-11 00:00 EDT", "2021-06-13 00:00 EDT", "2021-06-18 00:00 EDT"}}, // Either day field will do
+		{"0 0 13 * *", "2021-06-01 00:00 EDT", []string{"2021-06-13 00:00 EDT", "2021-07-13 00:00 EDT"}},
+		{"0 0 * * fri", "2021-06-01 00:00 EDT", []string{"2021-06-04 00:00 EDT", "2021-06-11 00:00 EDT"}},
+		{"0 0 * * 5-7", "2021-06-01 00:00 EDT", []string{"2021-06-04 00:00 EDT", "2021-06-05 00:00 EDT", "2021-06-06 00:00 EDT", "2021-06-11 00:00 EDT"}},
+		{"0 0 1 JAN-MAR *", "2021-06-01 00:00 EDT", []string{"2022-01-01 00:00 EST", "2022-02-01 00:00 EST", "2022-03-01 00:00 EST", "2023-01-01 00:00 EST"}},
+		{"0,30 */6 * * *", "2021-06-01 05:00 EDT", []string{"2021-06-01 06:00 EDT", "2021-06-01 06:30 EDT", "2021-06-01 12:00 EDT"}},
+		{"10-50/20 * * * *", "2021-06-01 05:00 EDT", []string{"2021-06-01 05:10 EDT", "2021-06-01 05:30 EDT", "2021-06-01 05:50 EDT", "2021-06-01 06:10 EDT"}},
+		{"0 0 31 4,6,9,11 *", "2021-06-01 00:00 EDT", []string{}}, // Months without a 31st
+		{"@DAILY", "2021-06-01 00:00 EDT", []string{"2021-06-02 00:00 EDT"}},
+		{"@midnight", "2021-06-01 23:59 EDT", []string{"2021-06-02 00:00 EDT"}},
+		{"@monthly", "2021-06-01 00:00 EDT", []string{"2021-07-01 00:00 EDT"}},
+		{"@yearly", "2021-06-01 00:00 EDT", []string{"2022-01-01 00:00 EST"}},
+		{"@annually", "2021-12-31 23:59 EST", []string{"2022-01-01 00:00 EST"}},
+		{"* 2 * * *", "2021-03-14 01:58 EST", []string{"2021-03-15 02:00 EDT", "2021-03-15 02:01 EDT"}}, // The whole hour was skipped
+		{"0 3 * * *", "2021-03-14 01:00 EST", []string{"2021-03-14 03:00 EDT"}},
+		{"*/30 1 * * *", "2021-11-07 00:00 EDT", []string{"2021-11-07 01:00 EDT", "2021-11-07 01:30 EDT", "2021-11-08 01:00 EST"}}, // Not again when 01:00 comes round a second time
+	}
+	for _, test := range tests {
+		s, err := ParseInLocation(test.expr, ny)
+		if err != nil {
+			t.Errorf("ParseInLocation(%q): %v", test.expr, err)
+			continue
+		}
+		var got []string
+		for tm := s.Next(at(test.after)); !tm.IsZero() && len(got) < len(test.want); tm = s.Next(tm) {
+			got = append(got, tm.Format("2006-01-02 15:04 MST"))
+		}
+		if strings.Join(got, ", ") != strings.Join(test.want, ", ") {
+			t.Errorf("%q after %s = %q, want %q", test.expr, test.after, got, test.want)
+		}
+	}
+}
+
+// Lord Howe Island's clocks go back half an hour, so the half hour before
+// 02:00 happens twice.
+func TestNextHalfHourChange(t *testing.T) {
+	lh, err := time.LoadLocation("Australia/Lord_Howe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		expr, after string
+		want        []string
+	}{
+		{"45 1 * * *", "2021-04-04T00:00:00+11:00", []string{"2021-04-04T01:45:00+11:00", "2021-04-05T01:45:00+10:30"}},
+		{"15 * * * *", "2021-04-04T00:00:00+11:00", []string{"2021-04-04T00:15:00+11:00", "2021-04-04T01:15:00+11:00", "2021-04-04T02:15:00+10:30"}},
+	}
+	for _, test := range tests {
+		s, err := ParseInLocation(test.expr, lh)
+		if err != nil {
+			t.Fatal(err)
+		}
+		after, err := time.Parse(time.RFC3339, test.after)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for tm := s.Next(after); len(got) < len(test.want); tm = s.Next(tm) {
+			got = append(got, tm.Format(time.RFC3339))
+		}
+		if strings.Join(got, ", ") != strings.Join(test.want, ", ") {
+			t.Errorf("%q after %s = %q, want %q", test.expr, test.after, got, test.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		expr, err string
+	}{
+		{"", `cron: "" has 0 fields, not 5`},
+		{"* * * *", `cron: "* * * *" has 4 fields, not 5`},
+		{"* * * * * *", `cron: "* * * * * *" has 6 fields, not 5`},
+		{"@every 5m", `cron: "@every 5m" has 2 fields, not 5`},
+		{"60 * * * *", `cron: "60 * * * *": minute field: 60 is not from 0 to 59`},
+		{"* 24 * * *", `cron: "* 24 * * *": hour field: 24 is not from 0 to 23`},
+		{"* * 0 * *", `cron: "* * 0 * *": day of month field: 0 is not from 1 to 31`},
+		{"* * * 13 *", `cron: "* * * 13 *": month field: 13 is not from 1 to 12`},
+		{"* * * * 8", `cron: "* * * * 8": day of week field: 8 is not from 0 to 7`},
+		{"0 0 * JANUARY *", `cron: "0 0 * JANUARY *": month field: "JANUARY" is not a number`},
+		{"0 17-9 * * *", `cron: "0 17-9 * * *": hour field: range "17-9" goes backwards`},
+		{"a-5 * * * *", `cron: "a-5 * * * *": minute field: "a" is not a number`},
+		{"*/0 * * * *", `cron: "*/0 * * * *": minute field: bad step in "*/0"`},
+		{"*/x * * * *", `cron: "*/x * * * *": minute field: bad step in "*/x"`},
+		{"1,,2 * * * *", `cron: "1,,2 * * * *": minute field: "" is not a number`},
+	}
+	for _, test := range tests {
+		_, err := Parse(test.expr)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("Parse(%q) = %v, want %s", test.expr, err, test.err)
+		}
+	}
+	if s, _ := Parse("@hourly"); s.String() != "@hourly" {
+		t.Errorf("String() = %q, want the expression it was parsed from", s.String())
+	}
+}
+
+// A run that takes longer than the time between runs makes the next one be
+// skipped, and cancelling stops the run that is going.
+func TestSchedulerSkipsBusyJobs(t *testing.T) {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	var mu sync.Mutex
+	var events []string
+	record := func(format string, args ...interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, fmt.Sprintf(format, args...))
+	}
+
+	s := Scheduler{Skipped: func(name string, at time.Time) {
+		record("%s skipped at %s", name, at.Format("15:04"))
+	}}
+	every2, _ := ParseInLocation("*/2 * * * *", time.UTC)
+	s.Add("slow", every2, func(ctx context.Context, at time.Time) {
+		record("slow started at %s", at.Format("15:04"))
+		select {
+		case <-clock.After(150 * time.Second):
+			record("slow finished at %s", clock.Now().Format("15:04:05"))
+		case <-ctx.Done():
+			record("slow cancelled at %s", clock.Now().Format("15:04:05"))
+		}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	clock.AfterFunc(7*time.Minute+30*time.Second, cancel)
+	if err := s.Run(ctx); err != context.Canceled {
+		t.Errorf("Run = %v, want %v", err, context.Canceled)
+	}
+
+	want := []string{
+		"slow started at 23:02",
+		"slow skipped at 23:04",
+		"slow finished at 23:04:30",
+		"slow started at 23:06",
+		"slow cancelled at 23:07:30",
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(events, "\n") != strings.Join(want, "\n") {
+		t.Errorf("events:\n%s\nwant:\n%s", strings.Join(events, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+// Run only returns once ctx is cancelled, even when nothing will run.
+func TestSchedulerRunWaitsForCancel(t *testing.T) {
+	never, _ := Parse("0 0 30 2 *")
+	for _, jobs := range [][]*Schedule{nil, {never}} {
+		restore := clock.Set(clock.NewVirtual(clock.Playground))
+		var s Scheduler
+		for _, sched := range jobs {
+			s.Add("never", sched, func(context.Context, time.Time) {
+				t.Error("a job that never fires ran")
+			})
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		clock.AfterFunc(time.Hour, cancel)
+		if err := s.Run(ctx); err != context.Canceled {
+			t.Errorf("Run with %d jobs = %v, want %v", len(jobs), err, context.Canceled)
+		}
+		if d := clock.Since(clock.Playground); d != time.Hour {
+			t.Errorf("Run with %d jobs returned after %v, want 1h0m0s", len(jobs), d)
+		}
+		restore()
+	}
+}
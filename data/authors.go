@@ -0,0 +1,52 @@
+package data
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"tutorial.sqlc.dev/app/tutorial"
+)
+
+// JSON
+
+// author is how a tutorial.Author is sent as JSON. tutorial.Author is
+// generated by sqlc from databases/schema.sql, so tags can't be added to it,
+// and it is converted to and from this instead.
+type author struct {
+	ID   int64      `json:"id,omitempty"` // New authors don't have one yet
+	Name string     `json:"name"`
+	Bio  nullString `json:"bio"`
+
+	password string // Unexported fields are never encoded
+}
+
+func fromAuthor(a tutorial.Author) author {
+	return author{ID: a.ID, Name: a.Name, Bio: nullString(a.Bio)}
+}
+
+func (a author) model() tutorial.Author {
+	return tutorial.Author{ID: a.ID, Name: a.Name, Bio: sql.NullString(a.Bio)}
+}
+
+// nullString is a sql.NullString that is a string in JSON, or null when it
+// isn't valid, rather than an object with String and Valid fields.
+type nullString sql.NullString
+
+func (s nullString) MarshalJSON() ([]byte, error) {
+	if !s.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.String)
+}
+
+func (s *nullString) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*s = nullString{}
+		return nil
+	}
+	if err := json.Unmarshal(b, &s.String); err != nil {
+		return err
+	}
+	s.Valid = true
+	return nil
+}
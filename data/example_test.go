@@ -0,0 +1,433 @@
+// Code generated by "go run . examples -w" from the Prints comments. DO NOT EDIT.
+
+package data_test
+
+import (
+	"github.com/omussell/go-by-example/clock"
+	"github.com/omussell/go-by-example/data"
+)
+
+func ExampleStringFunctions() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.StringFunctions()
+	// Output:
+	// Contains:  true
+	// Count:     2
+	// HasPrefix: true
+	// HasSuffix: true
+	// Index:     1
+	// Index:     -1
+	// Join:      a-b
+	// Repeat:    aaaaa
+	// Replace:   f00
+	// Replace:   f0o
+	// Split:     [a b c d e]
+	// ToLower:   test
+	// ToUpper:   TEST
+	// Fields:    [a b c]
+	// TrimSpace: a b
+	// 3...2...1...liftoff 19
+}
+
+func ExampleStringFormatting() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.StringFormatting()
+	// Output:
+	// {Bob 20}
+	// {name:Bob age:20}
+	// data.person{name:"Bob", age:20}
+	// data.person
+	// {10 5} &{width:10 height:5}
+	// true
+	// 123
+	// 1110
+	// !
+	// 1c8
+	// 78.900000
+	// 1.234000e+08
+	// "string"
+	// "\"string\""
+	// 6865782074686973
+	// tr
+	// |    12|   345|
+	// |000012|
+	// |  1.20|  3.45|
+	// |1.20  |3.45  |
+	// |   foo|     b|
+	// |foo   |b     |
+	// |   7|
+	// width  height  perim
+	// 10          5     30
+	// 3           4     14
+	// 120        80    400
+	// a string
+	// rect {width:3 height:4} is too small
+}
+
+func ExampleTextTemplates() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.TextTemplates()
+	// Output:
+	// Hello gopher!
+	// SHAPES
+	// shape          area    perim
+	// rectangle     12.00    14.00
+	// circle        78.54    31.42 large
+	// rectangle     25.00    25.00
+	// 3 shapes
+	// NOTHING
+	// shape          area    perim
+	// no shapes
+	// template: strict:1:2: executing "strict" at <.name>: map has no entry for key "name"
+}
+
+func ExampleHTMLTemplates() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.HTMLTemplates()
+	// Output:
+	// <p title="<script>alert("pwned")</script>"><script>alert("pwned")</script></p> <a href="/search?q=<script>alert("pwned")</script>">search</a>
+	// <p title="&lt;script&gt;alert(&#34;pwned&#34;)&lt;/script&gt;">&lt;script&gt;alert(&#34;pwned&#34;)&lt;/script&gt;</p> <a href="/search?q=%3cscript%3ealert%28%22pwned%22%29%3c%2fscript%3e">search</a>
+	// <h1>&lt;script&gt;alert(&#34;pwned&#34;)&lt;/script&gt;</h1>
+	// <table>
+	// <tr><th>shape</th><th>area</th><th>perim</th></tr>
+	// <tr><td>rectangle</td><td>12.00</td><td>14.00</td></tr>
+	// <tr class="large"><td>circle</td><td>78.54</td><td>31.42</td></tr>
+	// </table>
+	// <b>bold</b>
+}
+
+func ExampleRegularExpressions() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.RegularExpressions()
+	// Output:
+	// true
+	// true
+	// peach
+	// [0 5]
+	// [peach ea]
+	// [peach punch pinch]
+	// [peach punch]
+	// [[0 5] [6 11] [12 17]]
+	// true
+	// a <fruit>
+	// a PEACH
+	// 2009
+	// 10/11/2009
+	// error parsing regexp: missing closing ): `p([a-z]+ch`
+}
+
+func ExampleParsingLogs() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.ParsingLogs()
+	// Output:
+	// 127.0.0.1    GET   /apache_pb.gif       200  2326 2000-10-10 20:55
+	// 192.168.1.20 POST  /login               302     0 2009-11-10 23:00
+	// 10.0.0.5     GET   /favicon.ico         404   209 2009-11-10 23:00
+	// 203.0.113.9  GET   /search?q=go+regexp  200  1024 2009-11-10 23:00
+	// line 4: not an access log line
+	// line 5: not an access log line
+	// line 7: bad time "31/Nov/2009:23:00:05 +0000"
+	// Mozilla/5.0 (X11; Linux x86_64)
+}
+
+func ExampleRegexpOrStrings() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.RegexpOrStrings()
+	// Output:
+	// the parsers agree about all 7 lines
+}
+
+func ExampleJSON() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.JSON()
+	// Output:
+	// true
+	// 2.5
+	// ["apple","peach"]
+	// {"apple":5,"peach":2}
+	// {"name":"Ken Thompson","bio":null}
+	// {
+	//   "id": 3,
+	//   "name": "Ken Thompson",
+	//   "bio": null
+	// }
+	// 4 Rob Pike <nil>
+	// json: cannot unmarshal string into Go struct field author.id of type int64
+}
+
+func ExampleJSONNullString() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.JSONNullString()
+	// Output:
+	// {"ID":1,"Name":"Ken Thompson","Bio":{"String":"Created Unix","Valid":true}}
+	// {"ID":2,"Name":"Rob Pike","Bio":{"String":"","Valid":false}}
+	// {"id":1,"name":"Ken Thompson","bio":"Created Unix"}
+	// true
+	// {"id":2,"name":"Rob Pike","bio":null}
+	// true
+	// {ID:0 Name:Robert Griesemer Bio:{String: Valid:true}}
+}
+
+func ExampleDecodingJSON() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.DecodingJSON()
+	// Output:
+	// {ID:0 Name:Ken Thompson Bio:{String:Created Unix Valid:true}}
+	// {ID:0 Name:Dennis Ritchie Bio:{String: Valid:false}}
+	// json: unknown field "biography"
+	// author {"id": 7, "name": "Russ Cox"}
+	// Russ Cox
+	// float64 string []interface {} <nil>
+	// 7 Go
+}
+
+func ExampleXML() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.XML()
+	// Output:
+	// <?xml version="1.0" encoding="UTF-8"?>
+	// <people xmlns="https://gobyexample.com/people">
+	//   <person id="1">
+	//     <name>Bob</name>
+	//     <age>20</age>
+	//     <contact>
+	//       <email>bob@example.com</email>
+	//     </contact>
+	//     <!-- Bob's age is approximate -->
+	//   </person>
+	//   <person id="2">
+	//     <name>Alice</name>
+	//     <age>30</age>
+	//     <contact>
+	//       <email>alice@example.com</email>
+	//     </contact>
+	//   </person>
+	//   <person id="3">
+	//     <name>Fred</name>
+	//     <contact>
+	//       <email>fred@example.com</email>
+	//     </contact>
+	//   </person>
+	// </people>
+	// true
+	// 7 Ann <nil>
+	// expected element <people> in name space https://gobyexample.com/people but have https://example.com/other
+}
+
+func ExampleStreamingXML() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.StreamingXML()
+	// Output:
+	// start person [{{ id} 1}]
+	// comment " a comment "
+	// start name []
+	// text "Bob"
+	// end name
+	// end person
+	// 10000 people, with an average age of 49
+	// 825644 bytes read
+}
+
+func ExampleCSV() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.CSV()
+	// Output:
+	// name,age,note
+	// Bob,20,
+	// Alice,30,
+	// Fred,0,
+	// "Smith, Jane",41,"says ""hi"""
+	// name	age
+	// Bob	20
+	// [{Bob 20} {Smith; Jane 30} {Jon 42}]
+	// record on line 4: wrong number of fields
+	// line 5, column 1: age "forty" is not a number
+	// parse error on line 6, column 6: bare " in non-quoted-field
+}
+
+func ExampleNumberParsing() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.NumberParsing()
+	// Output:
+	// 1.234
+	// 123
+	// 456
+	// 255
+	// 789
+	// 135
+	// true
+	// strconv.Atoi: parsing "wat": invalid syntax
+	// strconv.ParseBool: parsing "yes": invalid syntax
+	// 127 strconv.ParseInt: parsing "300": value out of range
+	// ParseInt 300 true
+	// true
+	// +Inf strconv.ParseFloat: parsing "1e400": value out of range
+	// -101010
+	// ff
+	// 1.50
+	// 1.2345678e+03
+	// 0.10000000000000000555
+	// "tab\there" n=7
+}
+
+func ExampleNumericPrecision() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.NumericPrecision()
+	// Output:
+	// 600000000000
+	// 599999971328
+	// 9007199254740992 9007199254740992
+	// strconv.ParseInt: parsing "300000000000000000000": value out of range
+	// 3e+20 true
+	// 44 44
+	// 2 -2 3
+}
+
+func ExampleValidatingNumbers() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.ValidatingNumbers()
+	// Output:
+	// ok: 42
+	// error: a number is needed
+	// error: "+5" is not a whole number
+	// error: " 7" is not a whole number
+	// error: "1_000" is not a whole number
+	// error: "0x10" is not a whole number
+	// error: "3.5" is not a whole number
+	// error: 101 is out of range, it must be from 1 to 100
+	// error: 99999999999999999999 is out of range, it must be from 1 to 100
+	// error: -1 is out of range, it must be from 1 to 100
+	// ok: 2.5
+	// ok: -1000
+	// error: "NaN" is not a number
+	// error: "Inf" is not a number
+	// error: "0x1p-2" is not a number
+	// error: 1e400 is too big
+}
+
+func ExampleURLParsing() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.URLParsing()
+	// Output:
+	// postgres
+	// user:pass user
+	// pass
+	// host.com:5432 host.com 5432
+	// /path/to/thing /path/to%2Fthing
+	// frag
+	// k=v&k=w&x=1
+	// map[k:[v w] x:[1]] v [v w]
+	// parse "http://host.com:port/": invalid port ":port" after host
+	// q=go+%26+url&tag=net%2Furl&tag=100%25
+	// https://example.com/search%20results?q=go+%26+url&tag=net%2Furl&tag=100%25
+	// a%20b%2Fc%3Fd+e
+	// a+b%2Fc%3Fd%2Be
+	// a b/c <nil>
+	// https://example.com/docs/guide/setup.html
+	// https://example.com/docs/api/
+	// https://example.com/about
+	// https://example.com/docs/guide/intro.html?page=2
+	// https://example.com/docs/guide/intro.html?x=1#top
+	// https://cdn.example.com/app.js
+	// https://other.org/
+}
+
+func ExampleNormalisingURLs() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.NormalisingURLs()
+	// Output:
+	// http://example.com/a?a=1&b=2&flag <nil>
+	// "/relative/path" is not an absolute URL
+	// new  http://example.com
+	// seen http://EXAMPLE.com:80/
+	// seen http://example.com/#about
+	// new  http://example.com/about
+}
+
+func ExampleHashing() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.Hashing()
+	// Output:
+	// 1af1dfa857bf1d8814fe1af8983c18080019922e557f15a8a0d3db739d77aacb
+	// 1af1dfa857bf1d8814fe1af8983c18080019922e557f15a8a0d3db739d77aacb
+	// 900150983cd24fb0d6963f7d28e17f72
+	// a9993e364706816aba3e25717850c26c9cd0d89d
+	// ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad
+	// e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855
+}
+
+func ExampleChecksumsAndHMAC() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.ChecksumsAndHMAC()
+	// Output:
+	// cbf43926
+	// e3069283
+	// 5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843
+	// true
+	// false
+}
+
+func ExampleBase64AndHex() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.Base64AndHex()
+	// Output:
+	// YWJjMTIzIT8kKiYoKSctPUB+
+	// abc123!?$*&()'-=@~
+	// YWJjMTIzIT8kKiYoKSctPUB-
+	// YWI=
+	// YWI
+	// illegal base64 data at input byte 0
+	// 476f21
+	// Go! <nil>
+	// encoding/hex: invalid byte: U+0067 'g'
+	// 00000000  48 65 6c 6c 6f 2c 20 47  6f 70 68 65 72 73 21 0a  |Hello, Gophers!.|
+}
+
+func ExampleStringsAndRunes() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.StringsAndRunes()
+	// Output:
+	// 18
+	// 6
+	// 224 ส false
+	// e0 b8 aa e0 b8 a7
+	// 0 U+0E2A 'ส'
+	// 3 U+0E27 'ว'
+	// 6 U+0E31 'ั'
+	// 9 U+0E2A 'ส'
+	// 12 U+0E14 'ด'
+	// 15 U+0E35 'ี'
+	// so suea at 0 is 3 bytes
+	// so suea at 9 is 3 bytes
+	// false 5
+	// 0 U+0061
+	// 1 U+FFFD
+	// 2 U+0062
+	// 3 U+FFFD
+	// 4 U+FFFD
+	// "a\ufffdb\ufffd\ufffd"
+	// "a?b?"
+	// 'a' letter=true upper=false digit=false number=false space=false punct=false mark=false
+	// 'É' letter=true upper=true digit=false number=false space=false punct=false mark=false
+	// '7' letter=false upper=false digit=true number=true space=false punct=false mark=false
+	// ' ' letter=false upper=false digit=false number=false space=true punct=false mark=false
+	// '٣' letter=false upper=false digit=true number=true space=false punct=false mark=false
+	// 'ส' letter=true upper=false digit=false number=false space=false punct=false mark=false
+	// '!' letter=false upper=false digit=false number=false space=false punct=true mark=false
+	// '́' letter=false upper=false digit=false number=false space=false punct=false mark=true
+	// true É STRAßE
+}
+
+func ExampleTruncatingText() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	data.TruncatingText()
+	// Output:
+	// é bytes=3 runes=2 characters=1
+	// สวัสดี bytes=18 runes=6 characters=4
+	// น้ำ bytes=9 runes=3 characters=1
+	// 👍🏽 bytes=8 runes=2 characters=1
+	// 👩‍👩‍👧 bytes=18 runes=5 characters=1
+	// 🇬🇧 bytes=8 runes=2 characters=1
+	// hell… น้ำ… 🇬🇧…
+	// "cafe\xcc" "cafe" "café"
+}
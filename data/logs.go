@@ -0,0 +1,139 @@
+package data
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Parsing Logs
+
+// An accessLog is a line from a web server's access log, in the Common or
+// Combined Log Format that Apache and nginx write.
+type accessLog struct {
+	Host      string
+	User      string
+	Time      time.Time
+	Method    string
+	Path      string
+	Proto     string
+	Status    int
+	Size      int64 // 0 when the server logged -
+	Referer   string
+	UserAgent string
+}
+
+// logLine matches a line of an access log. The Combined format adds the
+// referer and user agent to the end of the Common one.
+var logLine = regexp.MustCompile(`^(?P<host>\S+) \S+ (?P<user>\S+) \[(?P<time>[^\]]+)\] "(?P<method>[A-Z]+) (?P<path>\S+) (?P<proto>[^"]+)" (?P<status>\d{3}) (?P<size>\d+|-)(?: "(?P<referer>[^"]*)" "(?P<agent>[^"]*)")?$`)
+
+const logTime = "02/Jan/2006:15:04:05 -0700"
+
+var errNotLog = errors.New("not an access log line")
+
+// parseLog parses a line of an access log with logLine.
+func parseLog(line string) (accessLog, error) {
+	m := logLine.FindStringSubmatch(line)
+	if m == nil {
+		return accessLog{}, errNotLog
+	}
+	group := func(name string) string {
+		return m[logLine.SubexpIndex(name)]
+	}
+	return newAccessLog(group("host"), group("user"), group("time"), group("method"), group("path"), group("proto"),
+		group("status"), group("size"), group("referer"), group("agent"))
+}
+
+// parseLogFields parses a line of an access log like parseLog, by cutting it
+// up with the strings package instead.
+func parseLogFields(line string) (accessLog, error) {
+	fields := strings.SplitN(line, " ", 4) // Host, identity, user and the rest
+	if len(fields) < 4 || !strings.HasPrefix(fields[3], "[") {
+		return accessLog{}, errNotLog
+	}
+	host, user, rest := fields[0], fields[2], fields[3][1:]
+
+	end := strings.IndexByte(rest, ']')
+	if end < 0 || !strings.HasPrefix(rest[end:], `] "`) {
+		return accessLog{}, errNotLog
+	}
+	ts, rest := rest[:end], rest[end+3:]
+
+	end = strings.IndexByte(rest, '"')
+	if end < 0 {
+		return accessLog{}, errNotLog
+	}
+	request := strings.SplitN(rest[:end], " ", 3)
+	if len(request) != 3 {
+		return accessLog{}, errNotLog
+	}
+	rest = strings.TrimPrefix(rest[end+1:], " ")
+
+	fields = strings.SplitN(rest, " ", 3) // Status, size and the rest
+	if len(fields) < 2 || len(fields[0]) != 3 {
+		return accessLog{}, errNotLog
+	}
+	referer, agent := "", ""
+	if len(fields) == 3 {
+		quoted := strings.Split(fields[2], `"`)
+		if len(quoted) != 5 || quoted[0] != "" || quoted[2] != " " || quoted[4] != "" {
+			return accessLog{}, errNotLog
+		}
+		referer, agent = quoted[1], quoted[3]
+	}
+	return newAccessLog(host, user, ts, request[0], request[1], request[2], fields[0], fields[1], referer, agent)
+}
+
+// newAccessLog converts the fields of a line that both parsers find.
+func newAccessLog(host, user, ts, method, path, proto, status, size, referer, agent string) (accessLog, error) {
+	e := accessLog{Host: host, User: user, Method: method, Path: path, Proto: proto, Referer: referer, UserAgent: agent}
+	var err error
+	if e.Time, err = time.Parse(logTime, ts); err != nil {
+		return accessLog{}, fmt.Errorf("bad time %q", ts)
+	}
+	if e.Status, err = strconv.Atoi(status); err != nil {
+		return accessLog{}, fmt.Errorf("bad status %q", status)
+	}
+	if size != "-" {
+		if e.Size, err = strconv.ParseInt(size, 10, 64); err != nil {
+			return accessLog{}, fmt.Errorf("bad size %q", size)
+		}
+	}
+	return e, nil
+}
+
+// parseLogs parses every line of an access log. Lines that can't be parsed
+// are reported as errors that say which line they are, and the rest are
+// still returned.
+func parseLogs(r io.Reader) ([]accessLog, []error) {
+	var entries []accessLog
+	var errs []error
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		e, err := parseLog(scanner.Text())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %v", n, err))
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return entries, errs
+}
+
+// sampleLog has lines from an access log, some of which are no good.
+const sampleLog = `127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326
+192.168.1.20 - - [10/Nov/2009:23:00:01 +0000] "POST /login HTTP/1.1" 302 - "https://example.com/" "Mozilla/5.0 (X11; Linux x86_64)"
+10.0.0.5 - - [10/Nov/2009:23:00:02 +0000] "GET /favicon.ico HTTP/1.1" 404 209 "-" "curl/7.68.0"
+this line is not from an access log
+10.0.0.5 - - [10/Nov/2009:23:00:03 +0000] "GET / HTTP/1.1" 2000 512
+203.0.113.9 - alice [10/Nov/2009:23:00:04 +0000] "GET /search?q=go+regexp HTTP/2.0" 200 1024 "-" "Go-http-client/2.0"
+203.0.113.9 - - [31/Nov/2009:23:00:05 +0000] "GET / HTTP/2.0" 200 512
+`
@@ -0,0 +1,22 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+// benchLines are the lines of sampleLog, including the ones that are no
+// good, as a real log can have those too.
+var benchLines = strings.Split(strings.TrimSpace(sampleLog), "\n")
+
+func benchmarkParse(b *testing.B, parse func(string) (accessLog, error)) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, l := range benchLines {
+			parse(l)
+		}
+	}
+}
+
+func BenchmarkParseLog(b *testing.B)       { benchmarkParse(b, parseLog) }
+func BenchmarkParseLogFields(b *testing.B) { benchmarkParse(b, parseLogFields) }
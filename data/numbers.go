@@ -0,0 +1,56 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Validating Numbers
+
+// parseWhole parses a whole number a user typed in, like a quantity in a
+// form, and checks it is between min and max. It is stricter than
+// strconv.Atoi, which allows a + sign, and its errors are written to be shown
+// to the user rather than say which strconv function failed.
+func parseWhole(s string, min, max int64) (int64, error) {
+	if s == "" {
+		return 0, errors.New("a number is needed")
+	}
+	if strings.HasPrefix(s, "+") {
+		return 0, fmt.Errorf("%q is not a whole number", s)
+	}
+	n, err := strconv.ParseInt(s, 10, 64) // Base 10 doesn't allow 0x or 1_000, which base 0 would
+	if err != nil {
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) && numErr.Err == strconv.ErrRange {
+			return 0, fmt.Errorf("%s is out of range, it must be from %d to %d", s, min, max)
+		}
+		return 0, fmt.Errorf("%q is not a whole number", s)
+	}
+	if n < min || n > max {
+		return 0, fmt.Errorf("%d is out of range, it must be from %d to %d", n, min, max)
+	}
+	return n, nil
+}
+
+// parseDecimal parses a number with a fractional part that a user typed in.
+// strconv.ParseFloat also accepts NaN, Inf and hexadecimal like 0x1p-2, and
+// returns ±Inf along with its error when a number is too big, none of which
+// a user means.
+func parseDecimal(s string) (float64, error) {
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789.-eE", r) {
+			return 0, fmt.Errorf("%q is not a number", s)
+		}
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if errors.Is(err, strconv.ErrRange) && math.IsInf(f, 0) {
+		return 0, fmt.Errorf("%s is too big", s)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	return f, nil
+}
@@ -0,0 +1,111 @@
+package data
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// people is the dataset for the XML and CSV examples.
+var people = []person{{"Bob", 20}, {"Alice", 30}, {"Fred", 0}}
+
+// XML
+
+// xmlPeople is the document the XML examples write and read. XMLName names
+// its element, along with the namespace it is in.
+type xmlPeople struct {
+	XMLName xml.Name    `xml:"https://gobyexample.com/people people"`
+	People  []xmlPerson `xml:"person"`
+}
+
+// xmlPerson is a person in XML. Fields are elements, unless their tag says
+// otherwise.
+type xmlPerson struct {
+	ID     int      `xml:"id,attr"`
+	Name   string   `xml:"name"`
+	Age    int      `xml:"age,omitempty"`
+	Emails []string `xml:"contact>email"` // Each in an email element, inside a contact element
+	Note   string   `xml:",comment"`
+}
+
+func toXML(ps []person) xmlPeople {
+	var doc xmlPeople
+	for i, p := range ps {
+		x := xmlPerson{ID: i + 1, Name: p.name, Age: p.age}
+		x.Emails = []string{strings.ToLower(p.name) + "@example.com"}
+		doc.People = append(doc.People, x)
+	}
+	return doc
+}
+
+// Streaming XML
+
+// writePeople writes a people document with n people in it, one at a time,
+// so it never has to be in memory all at once.
+func writePeople(w io.Writer, n int) error {
+	enc := xml.NewEncoder(w)
+	start := xml.StartElement{Name: xml.Name{Space: "https://gobyexample.com/people", Local: "people"}}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+	// Encode would name each element after its type, xmlPerson.
+	elem := xml.StartElement{Name: xml.Name{Local: "person"}}
+	for i := 0; i < n; i++ {
+		p := xmlPerson{ID: i + 1, Name: "person " + strconv.Itoa(i+1), Age: i % 100}
+		if err := enc.EncodeElement(p, elem); err != nil {
+			return err
+		}
+	}
+	if err := enc.EncodeToken(start.End()); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+// CSV
+
+// readPeople reads people from CSV with a header row, which says which
+// columns the name and age are in. Rows that can't be read are reported as
+// errors that say which line they are on, and the rest are still returned.
+func readPeople(r io.Reader, comma rune) ([]person, []error) {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	header, err := cr.Read()
+	if err != nil {
+		return nil, []error{err}
+	}
+	columns := map[string]int{}
+	for i, h := range header {
+		columns[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	name, okName := columns["name"]
+	age, okAge := columns["age"]
+	if !okName || !okAge {
+		return nil, []error{errors.New("the header needs name and age columns")}
+	}
+
+	var ps []person
+	var errs []error
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err) // A *csv.ParseError, which has the line number
+			continue
+		}
+		n, err := strconv.Atoi(record[age])
+		if err != nil {
+			line, col := cr.FieldPos(age)
+			errs = append(errs, fmt.Errorf("line %d, column %d: age %q is not a number", line, col, record[age]))
+			continue
+		}
+		ps = append(ps, person{record[name], n})
+	}
+	return ps, errs
+}
@@ -0,0 +1,91 @@
+package data
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Truncating Text
+
+const zeroWidthJoiner = '\u200d'
+
+// graphemeLen returns the length in bytes of the first grapheme cluster in s,
+// what a reader would call one character, which can be several runes. It
+// keeps together the cases that come up most, rather than all the rules of
+// Unicode's UAX #29: a rune followed by combining marks, like e and an
+// accent or Thai vowels and tone marks, emoji with skin tone modifiers or
+// variation selectors, emoji joined with zero width joiners, pairs of
+// regional indicators that make a flag, and \r\n. Thai and Lao SARA AM count
+// as marks too, though they are letters by category.
+func graphemeLen(s string) int {
+	r, n := utf8.DecodeRuneInString(s)
+	if n == 0 {
+		return 0
+	}
+	if r == '\r' && len(s) > 1 && s[1] == '\n' {
+		return 2
+	}
+	if isRegionalIndicator(r) {
+		if r2, n2 := utf8.DecodeRuneInString(s[n:]); isRegionalIndicator(r2) {
+			n += n2
+		}
+	}
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case unicode.Is(unicode.M, r), isSpacingMark(r), isEmojiModifier(r):
+			n += size
+		case r == zeroWidthJoiner:
+			n += size
+			if n < len(s) {
+				_, size = utf8.DecodeRuneInString(s[n:])
+				n += size // The joiner joins the next rune on
+			}
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// isSpacingMark reports whether r is one of the letters UAX #29 treats as a
+// spacing mark, which belongs to the character before it, like the last
+// vowel in น้ำ. Every other spacing mark is already in unicode.M.
+func isSpacingMark(r rune) bool { return r == '\u0e33' || r == '\u0eb3' }
+
+func isRegionalIndicator(r rune) bool { return r >= 0x1f1e6 && r <= 0x1f1ff }
+
+// isEmojiModifier reports whether r is a skin tone, which changes the emoji
+// before it.
+func isEmojiModifier(r rune) bool { return r >= 0x1f3fb && r <= 0x1f3ff }
+
+// graphemeCount returns the number of grapheme clusters in s.
+func graphemeCount(s string) int {
+	count := 0
+	for len(s) > 0 {
+		s = s[graphemeLen(s):]
+		count++
+	}
+	return count
+}
+
+// truncate shortens s to at most n grapheme clusters, adding an ellipsis
+// when it cuts anything off, which counts towards the n. Cutting at a byte,
+// or even a rune, could leave half a character, or a different one, like a
+// flag cut down to a letter, or an e without its accent.
+func truncate(s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+	end, i := 0, 0
+	for ; i < n-1 && end < len(s); i++ {
+		end += graphemeLen(s[end:])
+	}
+	if end == len(s) {
+		return s
+	}
+	if rest := s[end:]; len(rest) == graphemeLen(rest) {
+		return s // Exactly n, so nothing needs cutting
+	}
+	return s[:end] + "…"
+}
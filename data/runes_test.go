@@ -0,0 +1,63 @@
+package data
+
+import "testing"
+
+func TestGraphemeCount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"hello", 5},
+		{"e\u0301", 1},
+		{"caf\u00e9", 4}, // With the precomposed é
+		{"cafe\u0301", 4},
+		{"สวัสดี", 4},
+		{"น้ำ", 1}, // SARA AM is a letter, but a spacing mark to UAX #29
+		{"น้ำใจดี", 4},
+		{"ນ້ຳ", 1}, // As is Lao's
+		{"👍🏽", 1},
+		{"\u2764\ufe0f", 1}, // A heart and a variation selector asking for it as an emoji
+		{"👩‍👩‍👧", 1},
+		{"🇬🇧", 1},
+		{"🇬🇧🇫🇷🇩🇪", 3},
+		{"🇬", 1},
+		{"a\r\nb", 3},
+		{"\u0301a", 2}, // A mark with nothing before it stands alone
+	}
+	for _, test := range tests {
+		if got := graphemeCount(test.in); got != test.want {
+			t.Errorf("graphemeCount(%q) = %d, want %d", test.in, got, test.want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 5, "hello"},
+		{"hello world", 5, "hell…"},
+		{"", 3, ""},
+		{"hello", 0, ""},
+		{"hello", -1, ""},
+		{"hello", 1, "…"},
+		{"สวัสดีครับ", 4, "สวัส…"},
+		{"น้ำใจดี", 2, "น้ำ…"},
+		{"น้ำใจดี", 4, "น้ำใจดี"},
+		{"cafe\u0301 au lait", 5, "cafe\u0301…"},
+		{"👍🏽👍🏽👍🏽", 2, "👍🏽…"},
+		{"👩‍👩‍👧 family", 2, "👩‍👩‍👧…"},
+		{"🇬🇧🇫🇷🇩🇪", 3, "🇬🇧🇫🇷🇩🇪"},
+		{"🇬🇧🇫🇷🇩🇪", 2, "🇬🇧…"},
+		{"a\r\nb", 2, "a…"},
+	}
+	for _, test := range tests {
+		if got := truncate(test.in, test.n); got != test.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", test.in, test.n, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package data
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Normalising URLs
+
+// defaultPorts are the ports that go without saying for each scheme.
+var defaultPorts = map[string]string{"http": "80", "https": "443"}
+
+// normaliseURL rewrites an absolute URL so that URLs for the same page come
+// out the same, for finding ones that have already been seen. The scheme and
+// host are lowercased, the default port is removed, an empty path becomes /,
+// the query is sorted by key, and the fragment, which never reaches the
+// server, is dropped. See normaliseQuery for the query. The path is left
+// alone, as servers can treat its case and trailing slash as significant.
+func normaliseURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("%q is not an absolute URL", raw)
+	}
+	u.Scheme = strings.ToLower(u.Scheme) // Parse already lowercases it, but doesn't promise to
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if port == defaultPorts[u.Scheme] {
+		port = ""
+	}
+	u.Host = host
+	if port != "" || strings.Contains(host, ":") {
+		u.Host = net.JoinHostPort(host, port) // Puts IPv6 addresses back in brackets
+		u.Host = strings.TrimSuffix(u.Host, ":")
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	if u.RawQuery, err = normaliseQuery(u.RawQuery); err != nil {
+		return "", err
+	}
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String(), nil
+}
+
+// normaliseQuery sorts a query by key, keeping the values of each key in the
+// order they were given, and escapes every pair the same way, as
+// url.Values.Encode does. Unlike Encode, a key without an = keeps it that
+// way, as ?flag and ?flag= can mean different things to a server.
+func normaliseQuery(raw string) (string, error) {
+	type pair struct {
+		key, text string
+	}
+	var pairs []pair
+	for _, p := range strings.Split(raw, "&") {
+		if p == "" {
+			continue
+		}
+		if strings.Contains(p, ";") {
+			return "", fmt.Errorf("invalid semicolon separator in query")
+		}
+		k, v, hasValue := p, "", false
+		if i := strings.IndexByte(p, '='); i >= 0 {
+			k, v, hasValue = p[:i], p[i+1:], true
+		}
+		key, err := url.QueryUnescape(k)
+		if err != nil {
+			return "", err
+		}
+		text := url.QueryEscape(key)
+		if hasValue {
+			value, err := url.QueryUnescape(v)
+			if err != nil {
+				return "", err
+			}
+			text += "=" + url.QueryEscape(value)
+		}
+		pairs = append(pairs, pair{key, text})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return pairs[i].key < pairs[j].key })
+	texts := make([]string, len(pairs))
+	for i, p := range pairs {
+		texts[i] = p.text
+	}
+	return strings.Join(texts, "&"), nil
+}
@@ -0,0 +1,39 @@
+package data
+
+import "testing"
+
+func TestNormaliseURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+		err      string
+	}{
+		{"http://Example.COM", "http://example.com/", ""},
+		{"HTTP://example.com:80/a/b", "http://example.com/a/b", ""},
+		{"https://example.com:443/?b=2&a=1&a=0", "https://example.com/?a=1&a=0&b=2", ""},
+		{"https://example.com:8443/x#section", "https://example.com:8443/x", ""},
+		{"https://example.com:80/", "https://example.com:80/", ""}, // Only the scheme's own default port goes
+		{"http://[::1]:80/", "http://[::1]/", ""},
+		{"http://[::1]:8080/", "http://[::1]:8080/", ""},
+		{"http://example.com/A/b/?", "http://example.com/A/b/", ""},
+		{"http://example.com/?q=go+lang&q=url", "http://example.com/?q=go+lang&q=url", ""},
+		{"http://example.com/?q=go%20lang", "http://example.com/?q=go+lang", ""},
+		{"http://example.com/?a=%7e", "http://example.com/?a=~", ""},
+		{"http://example.com/?flag", "http://example.com/?flag", ""},
+		{"http://example.com/?flag=", "http://example.com/?flag=", ""},
+		{"http://example.com/?b&a=1&flag", "http://example.com/?a=1&b&flag", ""},
+		{"http://example.com/?a=1&&b=2&", "http://example.com/?a=1&b=2", ""},
+		{"/relative/path", "", `"/relative/path" is not an absolute URL`},
+		{"http://example.com/?a=1;b=2", "", "invalid semicolon separator in query"},
+		{"http://example.com/?a=%zz", "", `invalid URL escape "%zz"`},
+	}
+	for _, test := range tests {
+		got, err := normaliseURL(test.in)
+		gotErr := ""
+		if err != nil {
+			gotErr = err.Error()
+		}
+		if got != test.want || gotErr != test.err {
+			t.Errorf("normaliseURL(%q) = %q, %q, want %q, %q", test.in, got, gotErr, test.want, test.err)
+		}
+	}
+}
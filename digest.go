@@ -0,0 +1,106 @@
+// Digesting files
+//
+// The digest command works out several hashes of a file at once, the way the
+// Hashing section in data/7-data-manip.go shows: the file is read once and
+// written to every hash through an io.MultiWriter, so a large file is only
+// read from disk once and never held in memory.
+package main
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"strings"
+)
+
+// A digestHash is a hash the digest command can work out.
+type digestHash struct {
+	name string // As in the output, which is the format of BSD's md5 and sha256 tools
+	new  func() hash.Hash
+}
+
+var digestHashes = []digestHash{
+	{"MD5", md5.New},
+	{"SHA1", sha1.New},
+	{"SHA256", sha256.New},
+	{"SHA512", sha512.New},
+	{"CRC32", func() hash.Hash { return crc32.NewIEEE() }},
+}
+
+// findHashes returns the hashes named in a comma separated list, like
+// "sha256,md5", in the order given.
+func findHashes(list string) ([]digestHash, error) {
+	var found []digestHash
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		ok := false
+		for _, h := range digestHashes {
+			if strings.EqualFold(h.name, name) {
+				found, ok = append(found, h), true
+				break
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown hash %q", name)
+		}
+	}
+	return found, nil
+}
+
+// digest writes a line for each hash of what r reads, saying it is of name.
+func digest(w io.Writer, r io.Reader, name string, hashes []digestHash) error {
+	hs := make([]hash.Hash, len(hashes))
+	ws := make([]io.Writer, len(hashes))
+	for i, h := range hashes {
+		hs[i] = h.new()
+		ws[i] = hs[i]
+	}
+	if _, err := io.Copy(io.MultiWriter(ws...), r); err != nil {
+		return err
+	}
+	for i, h := range hashes {
+		fmt.Fprintf(w, "%s (%s) = %s\n", h.name, name, hex.EncodeToString(hs[i].Sum(nil)))
+	}
+	return nil
+}
+
+func digestCmd(args []string) error {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	list := fs.String("a", "md5,sha1,sha256", "the `hashes` to work out, from md5, sha1, sha256, sha512 and crc32")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	hashes, err := findHashes(*list)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return digest(os.Stdout, os.Stdin, "-", hashes)
+	}
+	for _, name := range fs.Args() {
+		if err := digestFile(name, hashes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func digestFile(name string, hashes []digestHash) error {
+	if name == "-" {
+		return digest(os.Stdout, os.Stdin, name, hashes)
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return digest(os.Stdout, f, name, hashes)
+}
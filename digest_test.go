@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// The test vectors are hashes of known inputs published with each algorithm,
+// and for CRC-32 the check value of its catalogue entry.
+func TestDigest(t *testing.T) {
+	tests := []struct {
+		hashes string
+		in     string
+		want   string
+	}{
+		{"md5", "", "MD5 (test) = d41d8cd98f00b204e9800998ecf8427e\n"},
+		{"md5", "abc", "MD5 (test) = 900150983cd24fb0d6963f7d28e17f72\n"},
+		{"md5", "The quick brown fox jumps over the lazy dog", "MD5 (test) = 9e107d9d372bb6826bd81d3542a419d6\n"},
+		{"sha1", "abc", "SHA1 (test) = a9993e364706816aba3e25717850c26c9cd0d89d\n"},
+		{"sha1", "abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq", "SHA1 (test) = 84983e441c3bd26ebaae4aa1f95129e5e54670f1\n"},
+		{"sha256", "", "SHA256 (test) = e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855\n"},
+		{"sha256", "abc", "SHA256 (test) = ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad\n"},
+		{"sha512", "abc", "SHA512 (test) = ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f\n"},
+		{"crc32", "123456789", "CRC32 (test) = cbf43926\n"},
+		{"sha256,md5", "abc", "SHA256 (test) = ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad\nMD5 (test) = 900150983cd24fb0d6963f7d28e17f72\n"},
+		{" SHA1, Md5 ", "abc", "SHA1 (test) = a9993e364706816aba3e25717850c26c9cd0d89d\nMD5 (test) = 900150983cd24fb0d6963f7d28e17f72\n"},
+	}
+	for _, test := range tests {
+		hashes, err := findHashes(test.hashes)
+		if err != nil {
+			t.Errorf("findHashes(%q): %v", test.hashes, err)
+			continue
+		}
+		var b bytes.Buffer
+		if err := digest(&b, strings.NewReader(test.in), "test", hashes); err != nil {
+			t.Errorf("digest(%q) with %s: %v", test.in, test.hashes, err)
+			continue
+		}
+		if got := b.String(); got != test.want {
+			t.Errorf("digest(%q) with %s = %q, want %q", test.in, test.hashes, got, test.want)
+		}
+	}
+}
+
+func TestDigestErrors(t *testing.T) {
+	for _, list := range []string{"sha3", "md5,sha3", "md5,", ""} {
+		if _, err := findHashes(list); err == nil {
+			t.Errorf("findHashes(%q) found them all, want an unknown hash error", list)
+		}
+	}
+	_, err := findHashes("md5,sha3")
+	if want := `unknown hash "sha3"`; err == nil || err.Error() != want {
+		t.Errorf("findHashes(%q) = %v, want %s", "md5,sha3", err, want)
+	}
+
+	// A read error stops the digest before anything is written, so a
+	// partial hash is never shown.
+	hashes, _ := findHashes("md5")
+	var b bytes.Buffer
+	if err := digest(&b, iotest.ErrReader(iotest.ErrTimeout), "test", hashes); err != iotest.ErrTimeout {
+		t.Errorf("digest of a failing reader = %v, want %v", err, iotest.ErrTimeout)
+	}
+	if b.Len() != 0 {
+		t.Errorf("digest of a failing reader wrote %q", b.String())
+	}
+}
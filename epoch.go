@@ -0,0 +1,124 @@
+// Converting timestamps
+//
+// The epoch command converts Unix timestamps to times people can read and
+// back, as the Epoch section in times/8-time.go does. It tells the time from
+// the clock package, so --now makes its output the same every time, as the
+// run command's does.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/omussell/go-by-example/clock"
+	"github.com/omussell/go-by-example/times"
+)
+
+// epochUnits are the units the epoch command can be told a timestamp is in.
+var epochUnits = map[string]time.Duration{
+	"s":  time.Second,
+	"ms": time.Millisecond,
+	"us": time.Microsecond,
+	"ns": time.Nanosecond,
+}
+
+func unitName(d time.Duration) string {
+	switch d {
+	case time.Second:
+		return "seconds"
+	case time.Millisecond:
+		return "milliseconds"
+	case time.Microsecond:
+		return "microseconds"
+	}
+	return "nanoseconds"
+}
+
+func epochCmd(args []string) error {
+	fs := flag.NewFlagSet("epoch", flag.ContinueOnError)
+	zone := fs.String("z", "Local", "show times in `zone`, like UTC or Europe/London, and read times without one in it")
+	unit := fs.String("u", "", "timestamps are in `unit`, s, ms, us or ns, rather than worked out from their size")
+	now := fs.String("now", "", "pretend it is `time`, as for run")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	loc, err := time.LoadLocation(*zone)
+	if err != nil {
+		return err
+	}
+	var forced time.Duration
+	if *unit != "" {
+		var ok bool
+		if forced, ok = epochUnits[*unit]; !ok {
+			return fmt.Errorf("unknown unit %q, use s, ms, us or ns", *unit)
+		}
+	}
+	if *now != "" {
+		t, err := parseNow(*now, time.Now())
+		if err != nil {
+			return err
+		}
+		defer clock.Set(clock.NewVirtual(t))()
+	}
+
+	if fs.NArg() == 0 {
+		printEpoch(os.Stdout, clock.Now().In(loc))
+		return nil
+	}
+	for i, arg := range fs.Args() {
+		if i > 0 {
+			fmt.Println()
+		}
+		if err := convertEpoch(os.Stdout, arg, loc, forced); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// convertEpoch converts s, which is either a Unix timestamp or a time in one
+// of the layouts ParseAny knows, to the other.
+func convertEpoch(w io.Writer, s string, loc *time.Location, unit time.Duration) error {
+	if n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64); err == nil {
+		var t time.Time
+		switch unit {
+		case 0:
+			t, unit = times.FromUnix(n)
+		case time.Second:
+			t = time.Unix(n, 0)
+		case time.Millisecond:
+			t = time.UnixMilli(n)
+		case time.Microsecond:
+			t = time.UnixMicro(n)
+		default:
+			t = time.Unix(0, n)
+		}
+		fmt.Fprintf(w, "%d is in %s\n", n, unitName(unit))
+		printEpoch(w, t.In(loc))
+		return nil
+	}
+	t, layout, err := times.ParseAny(s, loc, clock.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%q is in the %s layout\n", strings.TrimSpace(s), layout)
+	printEpoch(w, t.In(loc))
+	return nil
+}
+
+// printEpoch writes t, and t as a Unix timestamp in each unit.
+func printEpoch(w io.Writer, t time.Time) {
+	fmt.Fprintf(w, "%s\n", t.Format(time.RFC3339Nano))
+	fmt.Fprintf(w, "%s\n", t.Format("Monday, 2 January 2006 15:04:05 MST"))
+	fmt.Fprintf(w, "s   %d\n", t.Unix())
+	fmt.Fprintf(w, "ms  %d\n", t.UnixMilli())
+	fmt.Fprintf(w, "us  %d\n", t.UnixMicro())
+	if y := t.Year(); y >= 1678 && y <= 2261 { // UnixNano is only defined when it fits in an int64
+		fmt.Fprintf(w, "ns  %d\n", t.UnixNano())
+	}
+}
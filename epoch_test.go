@@ -0,0 +1,117 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/omussell/go-by-example/clock"
+	"github.com/omussell/go-by-example/section"
+)
+
+// The timestamps are all the Go playground's time, 23:00 UTC on the 10th of
+// November 2009, unless a unit is given that they aren't in.
+func TestConvertEpoch(t *testing.T) {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	tests := []struct {
+		in   string
+		zone string
+		unit time.Duration
+		want string // The first two lines of output
+	}{
+		{"1257894000", "UTC", 0, "1257894000 is in seconds\n2009-11-10T23:00:00Z"},
+		{"1257894000000", "UTC", 0, "1257894000000 is in milliseconds\n2009-11-10T23:00:00Z"},
+		{"1257894000000000", "UTC", 0, "1257894000000000 is in microseconds\n2009-11-10T23:00:00Z"},
+		{"1257894000000000000", "UTC", 0, "1257894000000000000 is in nanoseconds\n2009-11-10T23:00:00Z"},
+		{" 0\n", "UTC", 0, "0 is in seconds\n1970-01-01T00:00:00Z"},
+		{"-86400", "UTC", 0, "-86400 is in seconds\n1969-12-31T00:00:00Z"},
+		{"99999999999", "UTC", 0, "99999999999 is in seconds\n5138-11-16T09:46:39Z"},
+		{"100000000000", "UTC", 0, "100000000000 is in milliseconds\n1973-03-03T09:46:40Z"},
+
+		// -u
+		{"1257894000", "UTC", time.Second, "1257894000 is in seconds\n2009-11-10T23:00:00Z"},
+		{"1257894000", "UTC", time.Millisecond, "1257894000 is in milliseconds\n1970-01-15T13:24:54Z"},
+		{"1257894000", "UTC", time.Microsecond, "1257894000 is in microseconds\n1970-01-01T00:20:57.894Z"},
+		{"1257894000", "UTC", time.Nanosecond, "1257894000 is in nanoseconds\n1970-01-01T00:00:01.257894Z"},
+		{"1257894000000", "UTC", time.Second, "1257894000000 is in seconds\n41831-01-28T08:00:00Z"},
+
+		// -z
+		{"1257894000", "Asia/Kolkata", 0, "1257894000 is in seconds\n2009-11-11T04:30:00+05:30"},
+		{"2009-11-10T23:00:00Z", "Asia/Kolkata", 0, "\"2009-11-10T23:00:00Z\" is in the RFC 3339 layout\n2009-11-11T04:30:00+05:30"},
+		{"2009-11-10 18:00:00", "America/New_York", 0, "\"2009-11-10 18:00:00\" is in the date and time without a zone layout\n2009-11-10T18:00:00-05:00"},
+		{"Nov 10 18:00:00", "America/New_York", time.Millisecond, "\"Nov 10 18:00:00\" is in the syslog layout\n2009-11-10T18:00:00-05:00"}, // -u is only for timestamps
+	}
+	for _, test := range tests {
+		loc, err := time.LoadLocation(test.zone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var b strings.Builder
+		if err := convertEpoch(&b, test.in, loc, test.unit); err != nil {
+			t.Errorf("convertEpoch(%q, %s, %v): %v", test.in, test.zone, test.unit, err)
+			continue
+		}
+		lines := strings.SplitN(b.String(), "\n", 3)
+		if got := strings.Join(lines[:2], "\n"); got != test.want {
+			t.Errorf("convertEpoch(%q, %s, %v) =\n%s\nwant\n%s", test.in, test.zone, test.unit, got, test.want)
+		}
+	}
+
+	if err := convertEpoch(new(strings.Builder), "yesterday", time.UTC, 0); err == nil {
+		t.Error(`convertEpoch("yesterday") didn't fail`)
+	}
+}
+
+func TestPrintEpoch(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want string
+	}{
+		{clock.Playground, `2009-11-10T23:00:00Z
+Tuesday, 10 November 2009 23:00:00 UTC
+s   1257894000
+ms  1257894000000
+us  1257894000000000
+ns  1257894000000000000
+`},
+		// Too late for a timestamp in nanoseconds.
+		{time.Unix(1257894000000, 0).UTC(), `41831-01-28T08:00:00Z
+Friday, 28 January 41831 08:00:00 UTC
+s   1257894000000
+ms  1257894000000000
+us  1257894000000000000
+`},
+	}
+	for _, test := range tests {
+		var b strings.Builder
+		printEpoch(&b, test.t)
+		if b.String() != test.want {
+			t.Errorf("printEpoch(%v) =\n%s\nwant\n%s", test.t, b.String(), test.want)
+		}
+	}
+}
+
+func TestEpochCmd(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string // The first line of output
+		err  string
+	}{
+		{[]string{"-z", "UTC", "--now", "2009-11-10T23:00:00Z"}, "2009-11-10T23:00:00Z", ""},
+		{[]string{"-z", "Europe/London", "-u", "ms", "1257894000000"}, "1257894000000 is in milliseconds", ""},
+		{[]string{"-u", "m", "1"}, "", `unknown unit "m", use s, ms, us or ns`},
+		{[]string{"-z", "Mars/Base", "1"}, "", "unknown time zone Mars/Base"},
+	}
+	for _, test := range tests {
+		var err error
+		out := section.Capture(func() { err = epochCmd(test.args) })
+		gotErr := ""
+		if err != nil {
+			gotErr = err.Error()
+		}
+		got, _, _ := strings.Cut(out, "\n")
+		if got != test.want || gotErr != test.err {
+			t.Errorf("epoch %s = %q %q, want %q %q", strings.Join(test.args, " "), got, gotErr, test.want, test.err)
+		}
+	}
+}
@@ -2,13 +2,21 @@
 // Panic
 // Recover
 
-package main
+package errors
 
 import (
 	"errors"
 	"fmt"
+
+	"github.com/omussell/go-by-example/section"
 )
 
+// Sections are the examples in this file, in reading order.
+var Sections = []section.Section{
+	{Title: "Errors", Run: Errors},
+	{Title: "Recover", Run: Recover},
+}
+
 func f1(arg int) (int, error) { // Errors are the last return value and have type `error`, a built in interface
 	if arg == 42 {
 		return -1, errors.New("cant work with 42") // errors.New constructs a basic error value with the given error message
@@ -39,7 +47,8 @@ func mayPanic() {
 	panic("a problem")
 }
 
-func handleErrors() {
+// Errors
+func Errors() {
 	/*
 		The two loops below test out each of our error-returning functions. Note that the use of an inline error check on the if line is a common idiom in Go code.
 	*/
@@ -68,6 +77,20 @@ func handleErrors() {
 		fmt.Println(ae.prob)
 	}
 
+	/*
+		Prints:
+		f1 worked: 10
+		f1 failed: cant work with 42
+		f2 worked: 10
+		f2 failed: 42 - cant work with it
+		42
+		cant work with it
+	*/
+}
+
+// Panic
+// Recover
+func Recover() {
 	/*
 		A panic is used when something goes unexpectedly wrong.
 
@@ -90,10 +113,16 @@ func handleErrors() {
 
 	mayPanic()
 
+	/*
+		Prints:
+		Recovered. Error:
+		 a problem
+	*/
+
 	/*
 		This code will not run, because mayPanic panics.
 
-		The execution of handleErrors stops at the point of the panic and resumes in the deferred closure.
+		The execution of Recover stops at the point of the panic and resumes in the deferred closure.
 	*/
 	fmt.Println("After mayPanic()")
 }
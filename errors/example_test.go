@@ -0,0 +1,28 @@
+// Code generated by "go run . examples -w" from the Prints comments. DO NOT EDIT.
+
+package errors_test
+
+import (
+	"github.com/omussell/go-by-example/clock"
+	"github.com/omussell/go-by-example/errors"
+)
+
+func ExampleErrors() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	errors.Errors()
+	// Output:
+	// f1 worked: 10
+	// f1 failed: cant work with 42
+	// f2 worked: 10
+	// f2 failed: 42 - cant work with it
+	// 42
+	// cant work with it
+}
+
+func ExampleRecover() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	errors.Recover()
+	// Output:
+	// Recovered. Error:
+	//  a problem
+}
@@ -0,0 +1,144 @@
+// Generating Example functions
+//
+// Each section with Prints comments becomes an ExampleXxx function for the
+// function that runs it, with the comments as its Output block, so that
+// go test checks the examples and go doc shows them. As with verify, they run
+// on a virtual clock set to clock.Playground. The Prints comments stay the
+// only place the output is written down. The generated files are committed,
+// so a fresh clone tests them, and CI checks that regenerating them changes
+// nothing.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/omussell/go-by-example/section"
+)
+
+const modulePath = "github.com/omussell/go-by-example"
+
+// exampleFileName is the name of the generated file in each topic package.
+const exampleFileName = "example_test.go"
+
+// example renders the Example function for a section. It returns false,
+// along with the reason, for sections go test cannot check.
+func example(pkg string, ss *sectionSource) (string, bool, string) {
+	es := ss.expectations()
+	if len(es) == 0 {
+		return "", false, "has no Prints comments"
+	}
+
+	output := "Output:"
+	var lines []string
+	for _, e := range es {
+		if e.oneOf {
+			return "", false, "prints one of several lines"
+		}
+		if e.anyOrder {
+			output = "Unordered output:"
+		}
+		for _, l := range e.lines {
+			if pointer.MatchString(l) {
+				return "", false, "prints a pointer address"
+			}
+			lines = append(lines, l)
+		}
+	}
+
+	var b strings.Builder
+	name := ss.fn.Name.Name
+	fmt.Fprintf(&b, "func Example%s() {\n\tdefer clock.Set(clock.NewVirtual(clock.Playground))()\n\t%s.%s()\n\t// %s\n", name, pkg, name, output)
+	for _, l := range lines {
+		fmt.Fprintf(&b, "\t// %s\n", l)
+	}
+	b.WriteString("}\n")
+	return b.String(), true, ""
+}
+
+// An exampleFile is the generated file for one topic package.
+type exampleFile struct {
+	dir string // Package directory, relative to the module root
+	src []byte
+}
+
+// exampleFiles renders the examples of each topic package.
+func exampleFiles() ([]exampleFile, error) {
+	type topic struct {
+		dir, pkg string
+		body     bytes.Buffer
+		examples int
+	}
+	var topics []*topic
+	byDir := map[string]*topic{}
+	for _, s := range section.All() {
+		ss, err := source(s)
+		if err != nil {
+			return nil, err
+		}
+		dir := path.Dir(s.File)
+		t, ok := byDir[dir]
+		if !ok {
+			t = &topic{dir: dir, pkg: ss.file.Name.Name}
+			byDir[dir] = t
+			topics = append(topics, t)
+		}
+
+		fn, ok, why := example(t.pkg, ss)
+		if !ok {
+			fmt.Fprintf(&t.body, "\n// %s is left out as it %s.\n", s.Title, why)
+			continue
+		}
+		fmt.Fprintf(&t.body, "\n%s", fn)
+		t.examples++
+	}
+
+	var files []exampleFile
+	for _, t := range topics {
+		var b bytes.Buffer
+		fmt.Fprintf(&b, "// Code generated by \"go run . examples -w\" from the Prints comments. DO NOT EDIT.\n\n")
+		fmt.Fprintf(&b, "package %s_test\n", t.pkg)
+		if t.examples > 0 {
+			fmt.Fprintf(&b, "\nimport (\n\t%q\n\t%q\n)\n", modulePath+"/"+t.dir, modulePath+"/clock")
+		}
+		t.body.WriteTo(&b)
+		src, err := format.Source(b.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", t.dir, err)
+		}
+		files = append(files, exampleFile{t.dir, src})
+	}
+	return files, nil
+}
+
+func examplesCmd(args []string) error {
+	fs := flag.NewFlagSet("examples", flag.ContinueOnError)
+	write := fs.Bool("w", false, "write "+exampleFileName+" into each topic package instead of printing")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	files, err := exampleFiles()
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		name := path.Join(f.dir, exampleFileName)
+		if !*write {
+			fmt.Printf("// %s\n%s\n", name, f.src)
+			continue
+		}
+		if err := os.WriteFile(name, f.src, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// Markdown export
+//
+// The export command writes a Markdown file for each section, for pasting
+// into wikis and notes. The comments become prose between fenced code blocks,
+// and the output of running the section comes last, once it has been checked
+// against the Prints comments. With -cheatsheet it writes a single file with
+// just the code of every section, grouped by the topics listed at the top of
+// each file.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/omussell/go-by-example/section"
+)
+
+// markdown renders a section with its explanation and output.
+func markdown(s section.Section) (string, error) {
+	ss, err := source(s)
+	if err != nil {
+		return "", err
+	}
+	es := ss.expectations()
+	out, d := runAndDiff(s, es)
+	if len(es) > 0 && d != nil {
+		return "", fmt.Errorf("%s does not print what its comments say, see verify", s.ID())
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", s.Title)
+	fmt.Fprintf(&b, "From `%s`, run it with `go run . run %s`.\n", s.File, s.ID())
+
+	texts := []string{ss.slice(ss.fn.Pos(), ss.fn.End())}
+	for _, decl := range ss.uses() {
+		texts = append(texts, ss.text(decl))
+	}
+	var code []string
+	flush := func() {
+		if len(code) > 0 {
+			fmt.Fprintf(&b, "\n```go\n%s\n```\n", strings.Join(code, "\n"))
+		}
+		code = nil
+	}
+	for i, t := range texts {
+		if i > 0 {
+			// Each declaration gets its own block.
+			flush()
+		}
+		for _, seg := range segments(t) {
+			var prose []string
+			for _, p := range paragraphs(seg.doc) {
+				// The output is shown in full at the end instead.
+				if printsComment.MatchString(p.text) {
+					continue
+				}
+				prose = append(prose, p.text)
+			}
+			if len(prose) > 0 {
+				flush()
+				fmt.Fprintf(&b, "\n%s\n", strings.Join(prose, "\n\n"))
+			}
+			if seg.code != "" {
+				code = append(code, seg.code)
+			}
+		}
+	}
+	flush()
+
+	if out != "" {
+		fmt.Fprintf(&b, "\nOutput:\n\n```\n%s```\n", out)
+	}
+	return b.String(), nil
+}
+
+// bareCode is the code of a section with every comment removed, the body of
+// the section function followed by the declarations it uses.
+func bareCode(ss *sectionSource) (string, error) {
+	// The printer writes doc and line comments even without the file's
+	// comments, so they are cleared first.
+	for _, d := range append([]ast.Decl{ss.fn}, ss.uses()...) {
+		ast.Inspect(d, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncDecl:
+				n.Doc = nil
+			case *ast.GenDecl:
+				n.Doc = nil
+			case *ast.TypeSpec:
+				n.Doc, n.Comment = nil, nil
+			case *ast.ValueSpec:
+				n.Doc, n.Comment = nil, nil
+			case *ast.Field:
+				n.Doc, n.Comment = nil, nil
+			}
+			return true
+		})
+	}
+
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+	var b bytes.Buffer
+	if err := cfg.Fprint(&b, ss.fset, ss.fn.Body); err != nil {
+		return "", err
+	}
+	body := strings.Split(b.String(), "\n")
+	body = dedent(body[1 : len(body)-1]) // Drop the braces
+
+	code := []string{strings.Join(body, "\n")}
+	for _, d := range ss.uses() {
+		b.Reset()
+		if err := cfg.Fprint(&b, ss.fset, d); err != nil {
+			return "", err
+		}
+		code = append(code, b.String())
+	}
+	return strings.Join(code, "\n\n"), nil
+}
+
+// fileTopics returns the topics listed in the comment at the top of a topic
+// file, like "Slices, Maps, Ranges".
+func fileTopics(f *ast.File) string {
+	if len(f.Comments) == 0 || f.Comments[0].Pos() > f.Package {
+		return ""
+	}
+	paras := strings.Split(strings.TrimSpace(f.Comments[0].Text()), "\n\n")
+	return strings.Join(strings.Split(paras[len(paras)-1], "\n"), ", ")
+}
+
+func cheatsheet() (string, error) {
+	var b strings.Builder
+	b.WriteString("# Go by Example cheat-sheet\n")
+	file := ""
+	for _, s := range section.All() {
+		ss, err := source(s)
+		if err != nil {
+			return "", err
+		}
+		if s.File != file {
+			file = s.File
+			fmt.Fprintf(&b, "\n## %s\n\n`%s`\n", fileTopics(ss.file), s.File)
+		}
+		code, err := bareCode(ss)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n```go\n%s\n```\n", s.Title, code)
+	}
+	return b.String(), nil
+}
+
+func exportCmd(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	dir := fs.String("o", "export", "directory to write the Markdown files to")
+	sheet := fs.Bool("cheatsheet", false, "write a single cheatsheet.md with only the code of each section")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	check := section.All()
+	if fs.NArg() > 0 {
+		if *sheet {
+			return fmt.Errorf("the cheat-sheet always has every section")
+		}
+		check = nil
+		for _, name := range fs.Args() {
+			found, err := section.Find(name)
+			if err != nil {
+				return err
+			}
+			check = append(check, found...)
+		}
+	}
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		return err
+	}
+
+	if *sheet {
+		md, err := cheatsheet()
+		if err != nil {
+			return err
+		}
+		name := filepath.Join(*dir, "cheatsheet.md")
+		if err := os.WriteFile(name, []byte(md), 0o644); err != nil {
+			return err
+		}
+		fmt.Println("Wrote", name)
+		return nil
+	}
+
+	var index strings.Builder
+	index.WriteString("# Go by Example\n")
+	file := ""
+	for _, s := range check {
+		md, err := markdown(s)
+		if err != nil {
+			return err
+		}
+		name := s.Topic() + "-" + s.Name() + ".md"
+		if err := os.WriteFile(filepath.Join(*dir, name), []byte(md), 0o644); err != nil {
+			return err
+		}
+		if s.File != file {
+			file = s.File
+			fmt.Fprintf(&index, "\n## %s\n\n", s.File)
+		}
+		fmt.Fprintf(&index, "- [%s](%s)\n", s.Title, name)
+	}
+	name := filepath.Join(*dir, "README.md")
+	if err := os.WriteFile(name, []byte(index.String()), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %d sections and %s\n", len(check), name)
+	return nil
+}
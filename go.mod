@@ -1,3 +1,7 @@
 module github.com/omussell/go-by-example
 
-go 1.17
+go 1.23
+
+require tutorial.sqlc.dev/app v0.0.0-00010101000000-000000000000
+
+replace tutorial.sqlc.dev/app => ./databases
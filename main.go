@@ -1,10 +1,194 @@
+//go:generate go run . examples -w
+
 package main
 
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/omussell/go-by-example/advanced"
+	"github.com/omussell/go-by-example/async"
+	"github.com/omussell/go-by-example/basics"
+	"github.com/omussell/go-by-example/clock"
+	"github.com/omussell/go-by-example/collections"
+	"github.com/omussell/go-by-example/data"
+	"github.com/omussell/go-by-example/errors"
+	"github.com/omussell/go-by-example/section"
+	"github.com/omussell/go-by-example/sorting"
+	"github.com/omussell/go-by-example/times"
+)
+
+// The topic files, in reading order.
+func init() {
+	section.Register("basics/1-basics.go", basics.Sections...)
+	section.Register("collections/2-beyond-basics.go", collections.Sections...)
+	section.Register("advanced/3-advanced.go", advanced.Sections...)
+	section.Register("sorting/4-common-functions.go", sorting.Sections...)
+	section.Register("errors/5-errors.go", errors.Sections...)
+	section.Register("async/6-async.go", async.Sections...)
+	section.Register("data/7-data-manip.go", data.Sections...)
+	section.Register("times/8-time.go", times.Sections...)
+}
+
+// A command is one of the subcommands, like list or run.
+type command struct {
+	name    string
+	args    string // Argument synopsis shown in the usage message
+	summary string
+	run     func(args []string) error
+}
+
+var commands = []command{
+	{"list", "", "List the sections of every topic file", listCmd},
+	{"run", "[--all] [--now time | --fast] [section | topic]...", "Run sections, or whole topic files like basics", runCmd},
+	{"verify", "[-v] [section | topic]...", "Check sections print what their Prints comments say", verifyCmd},
+	{"examples", "[-w]", "Generate Example functions for go test from the Prints comments", examplesCmd},
+	{"site", "[-o dir]", "Write the sections as a static HTML site", siteCmd},
+	{"export", "[-o dir] [-cheatsheet] [section | topic]...", "Write the sections as Markdown, or a single cheat-sheet", exportCmd},
+	{"browse", "", "Browse the sections and run them in the terminal", browseCmd},
+	{"play", "[-timeout d] [-max-output n] [-resume | -version n | -history] <section>", "Edit a copy of a section and run it", playCmd},
+	{"search", "[-n results] <query>", "Find the sections about something, like recover or make(chan", searchCmd},
+	{"digest", "[-a hashes] [file]...", "Print the MD5, SHA-1 and SHA-256 of files, or other hashes", digestCmd},
+	{"epoch", "[-z zone] [-u unit] [--now time] [timestamp | time]...", "Convert Unix timestamps to times and back, or show the time now", epochCmd},
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s <command> [arguments]\n\nCommands:\n", os.Args[0])
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %s %s\t%s\n", c.name, c.args, c.summary)
+	}
+	w.Flush()
+}
+
 func main() {
-	basics()
-	beyondBasics()
-	advanced()
-	commonFunctions()
-	handleErrors()
-	asyncFunctions()
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	name := flag.Arg(0)
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		if err := c.run(flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
+	}
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", name)
+	usage()
+	os.Exit(2)
+}
+
+func listCmd(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(args, " "))
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	file := ""
+	for _, s := range section.All() {
+		if s.File != file {
+			if file != "" {
+				fmt.Fprintln(w)
+			}
+			file = s.File
+			fmt.Fprintf(w, "%s (%s)\n", s.Topic(), s.File)
+		}
+		fmt.Fprintf(w, "  %s\t%s\n", s.Name(), s.Title)
+	}
+	return w.Flush()
+}
+
+func runCmd(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	all := fs.Bool("all", false, "run every section")
+	now := fs.String("now", "", "pretend it is `time`, like \"Saturday 09:00\" or \"2024-02-29 13:30\", with sleeps passing instantly")
+	fast := fs.Bool("fast", false, "let sleeps pass instantly")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *now != "" {
+		t, err := parseNow(*now, time.Now())
+		if err != nil {
+			return err
+		}
+		defer clock.Set(clock.NewVirtual(t))()
+	} else if *fast {
+		defer clock.Set(clock.NewVirtual(time.Now()))()
+	}
+
+	var run []section.Section
+	if *all {
+		run = section.All()
+	}
+	for _, name := range fs.Args() {
+		found, err := section.Find(name)
+		if err != nil {
+			return err
+		}
+		run = append(run, found...)
+	}
+	if len(run) == 0 {
+		return fmt.Errorf("nothing to run, give a section name or --all")
+	}
+
+	for i, s := range run {
+		// Headers are only needed to tell several sections apart.
+		if len(run) > 1 {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Printf("== %s ==\n", s.Title)
+		}
+		s.Run()
+	}
+	return nil
+}
+
+// parseNow reads the time given to run --now. Without a date it is today, or
+// with just a weekday the next one, counting today. Times are local.
+func parseNow(s string, now time.Time) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	day, weekday := now, false
+	fields := strings.Fields(s)
+	if len(fields) > 0 {
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if strings.EqualFold(fields[0], d.String()) || strings.EqualFold(fields[0], d.String()[:3]) {
+				day, weekday = now.AddDate(0, 0, (int(d)-int(now.Weekday())+7)%7), true
+				fields = fields[1:]
+				break
+			}
+		}
+	}
+	clockTime := time.Time{}
+	switch len(fields) {
+	case 0:
+		if !weekday {
+			return time.Time{}, fmt.Errorf("cannot understand time %q", s)
+		}
+	case 1:
+		var err error
+		if clockTime, err = time.Parse("15:04", fields[0]); err != nil {
+			return time.Time{}, fmt.Errorf("cannot understand time %q", s)
+		}
+	default:
+		return time.Time{}, fmt.Errorf("cannot understand time %q", s)
+	}
+	y, m, d := day.Date()
+	return time.Date(y, m, d, clockTime.Hour(), clockTime.Minute(), 0, 0, time.Local), nil
 }
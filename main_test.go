@@ -0,0 +1,34 @@
+package main
+
+import (
+	"path"
+	"testing"
+
+	"github.com/omussell/go-by-example/section"
+)
+
+// Each package name, like data or times, should give every section in its
+// topic file, not a section whose title happens to contain it.
+func TestFindTopics(t *testing.T) {
+	want := map[string][]string{}
+	for _, s := range section.All() {
+		dir := path.Dir(s.File)
+		want[dir] = append(want[dir], s.ID())
+	}
+	for _, dir := range []string{"basics", "collections", "advanced", "sorting", "errors", "async", "data", "times"} {
+		found, err := section.Find(dir)
+		if err != nil {
+			t.Errorf("Find(%q): %v", dir, err)
+			continue
+		}
+		if len(found) != len(want[dir]) {
+			t.Errorf("Find(%q) gave %d sections, want the %d in its topic file", dir, len(found), len(want[dir]))
+			continue
+		}
+		for i, s := range found {
+			if s.ID() != want[dir][i] {
+				t.Errorf("Find(%q)[%d] = %s, want %s", dir, i, s.ID(), want[dir][i])
+			}
+		}
+	}
+}
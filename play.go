@@ -0,0 +1,421 @@
+// Playground
+//
+// The play command copies a section into a main package of its own, in a
+// temporary module, and opens it in an editor. Once the editor is closed it
+// is compiled and run, and can be edited again to try something else. The
+// program is stopped if it runs for too long or prints too much, so a
+// mistake like an endless loop doesn't hang the session. Each edited version
+// is kept, so an experiment can be picked up again later.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/omussell/go-by-example/section"
+)
+
+// playSource returns a main package that runs a section, made of the section
+// function and the declarations it uses.
+func playSource(s section.Section) ([]byte, error) {
+	ss, err := source(s)
+	if err != nil {
+		return nil, err
+	}
+	decls := append([]ast.Decl{ss.fn}, ss.uses()...)
+
+	// Only the imports the copied code uses are kept. References to
+	// packages are the only identifiers the parser leaves unresolved.
+	used := map[string]bool{}
+	for _, d := range decls {
+		ast.Inspect(d, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if x, ok := sel.X.(*ast.Ident); ok && x.Obj == nil {
+					used[x.Name] = true
+				}
+			}
+			return true
+		})
+	}
+	imports := map[string]string{} // Import spec by path
+	for _, f := range ss.files {
+		for _, spec := range f.Imports {
+			p, _ := strconv.Unquote(spec.Path.Value)
+			name := path.Base(p)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			if used[name] {
+				imports[p] = spec.Path.Value
+				if spec.Name != nil {
+					imports[p] = name + " " + spec.Path.Value
+				}
+			}
+		}
+	}
+	var paths []string
+	for p := range imports {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// %s, from %s.\n//\n// Edit it and close the editor to run it.\npackage main\n\n", s.Title, s.File)
+	if len(paths) > 0 {
+		// The standard library first, then the rest, the way goimports
+		// groups them.
+		var std, other []string
+		for _, p := range paths {
+			if strings.Contains(strings.SplitN(p, "/", 2)[0], ".") {
+				other = append(other, imports[p])
+			} else {
+				std = append(std, imports[p])
+			}
+		}
+		b.WriteString("import (\n")
+		for _, spec := range std {
+			fmt.Fprintf(&b, "\t%s\n", spec)
+		}
+		if len(std) > 0 && len(other) > 0 {
+			b.WriteString("\n")
+		}
+		for _, spec := range other {
+			fmt.Fprintf(&b, "\t%s\n", spec)
+		}
+		b.WriteString(")\n\n")
+	}
+	fmt.Fprintf(&b, "func main() {\n\t%s()\n}\n", ss.fn.Name.Name)
+	for _, d := range decls {
+		fmt.Fprintf(&b, "\n%s\n", ss.text(d))
+	}
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", s.ID(), err)
+	}
+	return src, nil
+}
+
+// copyImports copies the packages from this module that a program imports
+// into dir, from the embedded sources.
+func copyImports(dir string, src []byte) error {
+	f, err := parser.ParseFile(token.NewFileSet(), "main.go", src, parser.ImportsOnly)
+	if err != nil {
+		return nil // go build will say what is wrong
+	}
+	for _, spec := range f.Imports {
+		p, _ := strconv.Unquote(spec.Path.Value)
+		if !strings.HasPrefix(p, modulePath+"/") {
+			continue
+		}
+		pkg := strings.TrimPrefix(p, modulePath+"/")
+		entries, err := sources.ReadDir(pkg)
+		if err != nil {
+			return fmt.Errorf("%s cannot be used in the playground", p)
+		}
+		if err := os.MkdirAll(filepath.Join(dir, filepath.FromSlash(pkg)), 0o755); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			name := path.Join(pkg, e.Name())
+			if strings.HasSuffix(name, "_test.go") {
+				continue
+			}
+			b, err := sources.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepath.Join(dir, filepath.FromSlash(name)), b, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// The program is built in a copy of this module, so it has the same
+// dependencies.
+//
+//go:embed go.mod go.sum
+var moduleFiles embed.FS
+
+// playModule writes the go.mod and go.sum of this module into dir. The
+// module has the same path as this one, so the packages the program imports
+// from here can be copied in as they are. Modules replaced with a directory,
+// like the one in databases, are found from the current directory, so they
+// are only there when running from a checkout.
+func playModule(dir string) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	gomod, err := moduleFiles.ReadFile("go.mod")
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(gomod), "\n")
+	for i, l := range lines {
+		j := strings.Index(l, "=> ")
+		if j < 0 {
+			continue
+		}
+		target := strings.TrimSpace(l[j+3:])
+		if strings.HasPrefix(target, "./") || strings.HasPrefix(target, "../") {
+			lines[i] = l[:j+3] + filepath.Join(wd, target)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		return err
+	}
+	gosum, err := moduleFiles.ReadFile("go.sum")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, "go.sum"), gosum, 0o644)
+}
+
+// playHistory is where the edited versions of a section are kept, as 1.go,
+// 2.go and so on.
+func playHistory(s section.Section) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "go-by-example", "play", s.Topic(), s.Name()), nil
+}
+
+// versions returns the numbers of the versions kept in a history directory,
+// oldest first.
+func versions(dir string) ([]int, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var vs []int
+	for _, e := range entries {
+		if n, err := strconv.Atoi(strings.TrimSuffix(e.Name(), ".go")); err == nil && strings.HasSuffix(e.Name(), ".go") {
+			vs = append(vs, n)
+		}
+	}
+	sort.Ints(vs)
+	return vs, nil
+}
+
+func versionFile(dir string, n int) string {
+	return filepath.Join(dir, strconv.Itoa(n)+".go")
+}
+
+// keep adds src to the history, unless it is the same as the latest version.
+func keep(dir string, src []byte) (int, error) {
+	vs, err := versions(dir)
+	if err != nil {
+		return 0, err
+	}
+	n := 1
+	if len(vs) > 0 {
+		last := vs[len(vs)-1]
+		if prev, err := os.ReadFile(versionFile(dir, last)); err == nil && bytes.Equal(prev, src) {
+			return last, nil
+		}
+		n = last + 1
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+	return n, os.WriteFile(versionFile(dir, n), src, 0o644)
+}
+
+// edit opens a file in the user's editor, $VISUAL or $EDITOR, or vi.
+func edit(name string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	args := append(strings.Fields(editor), name)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %v", editor, err)
+	}
+	return nil
+}
+
+// A cappedWriter passes on at most n bytes, and calls full the first time
+// there are more.
+type cappedWriter struct {
+	w    io.Writer
+	n    int
+	full func()
+	over bool
+}
+
+func (c *cappedWriter) Write(p []byte) (int, error) {
+	if len(p) > c.n {
+		c.w.Write(p[:c.n])
+		c.n = 0
+		if !c.over {
+			c.over = true
+			c.full()
+		}
+		return len(p), nil
+	}
+	c.n -= len(p)
+	return c.w.Write(p)
+}
+
+// playRun compiles the program in dir, and runs it for at most timeout,
+// showing at most limit bytes of what it prints.
+func playRun(dir string, timeout time.Duration, limit int) error {
+	build := exec.Command("go", "build", "-o", "play", ".")
+	build.Dir = dir
+	build.Stdout, build.Stderr = os.Stdout, os.Stdout
+	if err := build.Run(); err != nil {
+		return errors.New("it does not compile")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	out := &cappedWriter{w: os.Stdout, n: limit, full: cancel}
+	// The program is run directly rather than with go run, so that stopping
+	// it stops the program rather than just the go command.
+	cmd := exec.CommandContext(ctx, filepath.Join(dir, "play"))
+	cmd.Dir = dir
+	cmd.Stdout, cmd.Stderr = out, out
+	err := cmd.Run()
+	switch {
+	case out.over:
+		return fmt.Errorf("stopped after printing %d bytes, see -max-output", limit)
+	case ctx.Err() == context.DeadlineExceeded:
+		return fmt.Errorf("stopped after running for %v, see -timeout", timeout)
+	}
+	return err
+}
+
+func playCmd(args []string) error {
+	fs := flag.NewFlagSet("play", flag.ContinueOnError)
+	timeout := fs.Duration("timeout", 10*time.Second, "stop the program after `duration`")
+	limit := fs.Int("max-output", 64<<10, "stop the program after it prints `n` bytes")
+	resume := fs.Bool("resume", false, "start from the latest edited version")
+	version := fs.Int("version", 0, "start from edited version `n`")
+	history := fs.Bool("history", false, "list the edited versions instead")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("play takes one section")
+	}
+	found, err := section.Find(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if len(found) > 1 {
+		return fmt.Errorf("%s is a topic, play takes one section", fs.Arg(0))
+	}
+	s := found[0]
+
+	hist, err := playHistory(s)
+	if err != nil {
+		return err
+	}
+	vs, err := versions(hist)
+	if err != nil {
+		return err
+	}
+	if *history {
+		if len(vs) == 0 {
+			fmt.Printf("%s has not been edited\n", s.ID())
+		}
+		for _, n := range vs {
+			fi, err := os.Stat(versionFile(hist, n))
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%3d  %s  %s\n", n, fi.ModTime().Format("2006-01-02 15:04"), versionFile(hist, n))
+		}
+		return nil
+	}
+
+	src, err := playSource(s)
+	if err != nil {
+		return err
+	}
+	if *resume && len(vs) > 0 {
+		*version = vs[len(vs)-1]
+	}
+	if *version > 0 {
+		if src, err = os.ReadFile(versionFile(hist, *version)); err != nil {
+			return fmt.Errorf("no version %d of %s, see play -history", *version, s.ID())
+		}
+	}
+
+	if _, err := exec.LookPath("go"); err != nil {
+		return fmt.Errorf("play needs the go command to compile the program")
+	}
+	dir, err := os.MkdirTemp("", "go-by-example-play-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	if err := playModule(dir); err != nil {
+		return err
+	}
+	file := filepath.Join(dir, "main.go")
+	if err := os.WriteFile(file, src, 0o644); err != nil {
+		return err
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	for {
+		if err := edit(file); err != nil {
+			return err
+		}
+		edited, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(edited, src) {
+			n, err := keep(hist, edited)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Kept as version %d, in %s\n", n, versionFile(hist, n))
+		}
+
+		if err := copyImports(dir, edited); err != nil {
+			return err
+		}
+		fmt.Printf("Running %s\n", file)
+		if err := playRun(dir, *timeout, *limit); err != nil {
+			fmt.Println(err)
+		}
+		fmt.Print("\nPress enter to edit it again, or q to quit: ")
+		line, err := in.ReadString('\n')
+		if err != nil || strings.TrimSpace(line) == "q" {
+			fmt.Println()
+			return nil
+		}
+	}
+}
@@ -0,0 +1,305 @@
+// Searching the examples
+//
+// The search command ranks sections by how well they match a query, using
+// their titles, comments, the identifiers their code uses, like sort.Strings
+// or recover, and the code itself, so fragments like "make(chan" work too.
+// The index is built from the embedded sources, so it works from the compiled
+// binary on its own.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/omussell/go-by-example/section"
+)
+
+// A searchDoc is everything about a section that a query can match.
+type searchDoc struct {
+	section  section.Section
+	idents   map[string]bool // Lower case, like "sort.strings" and "recover"
+	comments string          // Lower case text of every comment
+	lines    []searchLine
+}
+
+// A searchLine is a line of the section's source, used to match code and to
+// show where a match is.
+type searchLine struct {
+	text    string
+	file    string
+	line    int
+	comment bool // The whole line is a comment
+}
+
+// Words that say nothing about which section is wanted.
+var stopWords = map[string]bool{}
+
+func init() {
+	for _, w := range strings.Fields("a an and are can do does how i in is it my of on or the to use using what when with you") {
+		stopWords[w] = true
+	}
+}
+
+func newSearchDoc(s section.Section) (*searchDoc, error) {
+	ss, err := source(s)
+	if err != nil {
+		return nil, err
+	}
+	doc := &searchDoc{section: s, idents: map[string]bool{}}
+	var comments []string
+	for _, d := range append([]ast.Decl{ss.fn}, ss.uses()...) {
+		ast.Inspect(d, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.SelectorExpr:
+				if x, ok := n.X.(*ast.Ident); ok {
+					doc.idents[strings.ToLower(x.Name+"."+n.Sel.Name)] = true
+				}
+			case *ast.Ident:
+				doc.idents[strings.ToLower(n.Name)] = true
+			}
+			return true
+		})
+
+		// The section function is shown without its doc comment, as on the
+		// site, and the declarations it uses with theirs.
+		text := ss.slice(ss.fn.Pos(), ss.fn.End())
+		if d != ss.fn {
+			text = ss.text(d)
+		}
+		end := ss.fset.Position(d.End())
+		start := end
+		start.Line -= strings.Count(text, "\n")
+		inBlock := false
+		for i, l := range strings.Split(text, "\n") {
+			t := strings.TrimSpace(l)
+			comment := inBlock || strings.HasPrefix(t, "//") || strings.HasPrefix(t, "/*")
+			if strings.HasPrefix(t, "/*") {
+				inBlock = true
+			}
+			if strings.Contains(t, "*/") {
+				inBlock = false
+			}
+			doc.lines = append(doc.lines, searchLine{t, start.Filename, start.Line + i, comment})
+		}
+	}
+	for _, l := range doc.lines {
+		if l.comment {
+			comments = append(comments, l.text)
+		} else if i := strings.Index(l.text, "//"); i >= 0 {
+			comments = append(comments, l.text[i:])
+		}
+	}
+	doc.comments = strings.ToLower(strings.Join(comments, " "))
+	return doc, nil
+}
+
+// stem drops a plural s, so "sends" also finds "send".
+func stem(term string) string {
+	if len(term) > 3 && strings.HasSuffix(term, "s") && !strings.HasSuffix(term, "ss") {
+		return term[:len(term)-1]
+	}
+	return term
+}
+
+// score says how well the doc matches the terms, and how many of the terms
+// match at all.
+func (doc *searchDoc) score(terms []string) (score, matched int) {
+	title := strings.ToLower(doc.section.Title)
+	for _, term := range terms {
+		s := stem(term)
+		points := 0
+		if strings.Contains(title, s) {
+			points += 10
+		}
+		if doc.idents[term] {
+			points += 8
+		} else {
+			for id := range doc.idents {
+				if strings.Contains(id, s) {
+					points += 3
+					break
+				}
+			}
+		}
+		if n := strings.Count(doc.comments, s); n > 0 {
+			if n > 3 {
+				n = 3
+			}
+			points += 2 * n
+		}
+		for _, l := range doc.lines {
+			if !l.comment && strings.Contains(strings.ToLower(l.text), s) {
+				points += 4
+				break
+			}
+		}
+		if points > 0 {
+			matched++
+			score += points
+		}
+	}
+	return score, matched
+}
+
+// snippet is the line matching the most terms, preferring code to comments.
+func (doc *searchDoc) snippet(terms []string) (searchLine, bool) {
+	best, bestHits := searchLine{}, 0
+	for _, l := range doc.lines {
+		hits := 0
+		lower := strings.ToLower(l.text)
+		for _, term := range terms {
+			if strings.Contains(lower, stem(term)) {
+				hits += 2
+			}
+		}
+		if hits > 0 && !l.comment {
+			hits++
+		}
+		if hits > bestHits {
+			best, bestHits = l, hits
+		}
+	}
+	return best, bestHits > 0
+}
+
+// highlightTerms marks every match of the terms in a line with ANSI reverse video.
+// The terms are matched case insensitively in the line itself, rather than in
+// a lower cased copy, as lower casing can change the length of a line, like
+// İ, which would throw the offsets out. A plural s dropped by stem is marked
+// too, where the line has one.
+func highlightTerms(line string, terms []string) string {
+	marked := make([]bool, len(line))
+	for _, term := range terms {
+		pattern := regexp.QuoteMeta(stem(term))
+		if stem(term) != term {
+			pattern += "s?"
+		}
+		re := regexp.MustCompile("(?i)" + pattern)
+		for _, m := range re.FindAllStringIndex(line, -1) {
+			for k := m[0]; k < m[1]; k++ {
+				marked[k] = true
+			}
+		}
+	}
+	var b strings.Builder
+	on := false
+	for i := 0; i < len(line); i++ {
+		if marked[i] != on {
+			on = marked[i]
+			if on {
+				b.WriteString("\x1b[7m")
+			} else {
+				b.WriteString("\x1b[0m")
+			}
+		}
+		b.WriteByte(line[i])
+	}
+	if on {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
+
+// queryTerms splits a query into lower case terms, without stop words.
+func queryTerms(query string) []string {
+	var terms []string
+	for _, t := range strings.Fields(strings.ToLower(query)) {
+		if !stopWords[t] {
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// A searchResult is a section matching a query.
+type searchResult struct {
+	doc            *searchDoc
+	score, matched int
+}
+
+// search ranks the sections matching a query, best first.
+func search(docs []*searchDoc, query string) []searchResult {
+	terms := queryTerms(query)
+	var results []searchResult
+	for _, doc := range docs {
+		score, matched := doc.score(terms)
+		if matched > 0 {
+			results = append(results, searchResult{doc, score, matched})
+		}
+	}
+	// Matching more of the query counts for more than matching some of it
+	// many times.
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].matched != results[j].matched {
+			return results[i].matched > results[j].matched
+		}
+		return results[i].score > results[j].score
+	})
+	return results
+}
+
+// searchIndex builds the search docs of every section.
+func searchIndex() ([]*searchDoc, error) {
+	var docs []*searchDoc
+	for _, s := range section.All() {
+		doc, err := newSearchDoc(s)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// isTerminal reports whether f is a terminal.
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
+
+func searchCmd(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	limit := fs.Int("n", 10, "show at most `n` results")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *limit < 1 {
+		fs.Usage()
+		return fmt.Errorf("-n must be at least 1, not %d", *limit)
+	}
+	query := strings.Join(fs.Args(), " ")
+	terms := queryTerms(query)
+	if len(terms) == 0 {
+		return fmt.Errorf("nothing to search for")
+	}
+
+	docs, err := searchIndex()
+	if err != nil {
+		return err
+	}
+	results := search(docs, query)
+	if len(results) == 0 {
+		return fmt.Errorf("no sections match %q", query)
+	}
+	if len(results) > *limit {
+		results = results[:*limit]
+	}
+	color := isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""
+	for i, r := range results {
+		s := r.doc.section
+		fmt.Printf("%d. %s (%s)    go run . run %s\n", i+1, s.Title, s.File, s.ID())
+		if l, ok := r.doc.snippet(terms); ok {
+			text := l.text
+			if color {
+				text = highlightTerms(text, terms)
+			}
+			fmt.Printf("   %s:%d: %s\n", l.file, l.line, text)
+		}
+	}
+	return nil
+}
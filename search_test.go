@@ -0,0 +1,76 @@
+package main
+
+import "testing"
+
+func TestSearch(t *testing.T) {
+	docs, err := searchIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		query string
+		top   string // Title of the best result
+	}{
+		{"recover", "Recover"},
+		{"sort.Strings", "Sorting"},
+		{"make(chan", "Channels"},
+		{"closures", "Anonymous Functions and Closures"},
+		{"timers", "Timers"},
+		{"sends", "Channels"}, // Found as send
+		{"how do I use a ticker", "Tickers"},
+		{"parse time", "Time Parsing"},
+		{"sha256", "Hashing"},
+	}
+	for _, test := range tests {
+		results := search(docs, test.query)
+		if len(results) == 0 {
+			t.Errorf("search(%q) found nothing, want %s first", test.query, test.top)
+			continue
+		}
+		if got := results[0].doc.section.Title; got != test.top {
+			t.Errorf("search(%q) put %s first, want %s", test.query, got, test.top)
+		}
+	}
+	if results := search(docs, "xyzzy"); len(results) != 0 {
+		t.Errorf("search(%q) found %d sections, want none", "xyzzy", len(results))
+	}
+}
+
+func TestStem(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"sends", "send"},
+		{"closures", "closure"},
+		{"class", "class"}, // Not a plural
+		{"its", "its"},     // Too short to tell
+		{"recover", "recover"},
+	}
+	for _, test := range tests {
+		if got := stem(test.in); got != test.want {
+			t.Errorf("stem(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
+
+func TestHighlightTerms(t *testing.T) {
+	const on, off = "\x1b[7m", "\x1b[0m"
+	tests := []struct {
+		line  string
+		terms []string
+		want  string
+	}{
+		{"ch <- msg // Sends msg", []string{"sends"}, "ch <- msg // " + on + "Sends" + off + " msg"},
+		{"ch <- msg // Send msg", []string{"sends"}, "ch <- msg // " + on + "Send" + off + " msg"},
+		{"sort.Strings(strs)", []string{"sort.strings", "strs"}, on + "sort.Strings" + off + "(" + on + "strs" + off + ")"},
+		{"Café CAFÉ cafe", []string{"café"}, on + "Café" + off + " " + on + "CAFÉ" + off + " cafe"},
+		// İ is 2 bytes, but lower cased it is 3, which mustn't move the
+		// highlight along.
+		{"İİ send", []string{"send"}, "İİ " + on + "send" + off},
+		{"日本語 and 語", []string{"語"}, "日本" + on + "語" + off + " and " + on + "語" + off},
+		{"no match", []string{"chan"}, "no match"},
+	}
+	for _, test := range tests {
+		if got := highlightTerms(test.line, test.terms); got != test.want {
+			t.Errorf("highlightTerms(%q, %q) = %q, want %q", test.line, test.terms, got, test.want)
+		}
+	}
+}
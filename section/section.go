@@ -0,0 +1,145 @@
+// Package section is the registry of runnable examples.
+//
+// Each topic package lists its sections, and main registers the topics in
+// reading order, so that each example can be listed and run on its own.
+package section
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+// A Section is a single runnable example, like "Loops" in basics/1-basics.go
+// or "Channels" in async/6-async.go.
+type Section struct {
+	File  string // Topic file the section is defined in, relative to the module root
+	Title string // Heading used in the topic file
+	Run   func()
+}
+
+var sections []Section
+
+// Register adds the sections of a topic file, in the order they appear in
+// the file.
+func Register(file string, ss ...Section) {
+	for _, s := range ss {
+		s.File = file
+		sections = append(sections, s)
+	}
+}
+
+// All returns every registered section in reading order.
+func All() []Section {
+	return sections
+}
+
+// Name is how a section is referred to on the command line, e.g. "if-else"
+// for "If/Else".
+func (s Section) Name() string {
+	return Slug(s.Title)
+}
+
+// ID names a section uniquely, as "topic/name", e.g. "advanced/errors".
+func (s Section) ID() string {
+	return s.Topic() + "/" + s.Name()
+}
+
+// Topic is the name of the file a section is in, without the number prefix
+// and extension, e.g. "basics" for basics/1-basics.go.
+func (s Section) Topic() string {
+	t := strings.TrimSuffix(path.Base(s.File), ".go")
+	if i := strings.Index(t, "-"); i >= 0 {
+		t = t[i+1:]
+	}
+	return t
+}
+
+// Slug turns a title into lower case words separated by dashes.
+func Slug(title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		default:
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// Find looks up sections by name. A topic gives every section in its file,
+// and can be named by the file, as in "data/7-data-manip.go", by its package
+// directory, "data", or without the number prefix, "data-manip". Otherwise
+// the name is matched against section IDs and titles, first exactly and then
+// as a unique part of a title, so "closures" finds "Anonymous Functions and
+// Closures".
+func Find(name string) ([]Section, error) {
+	var found []Section
+	for _, s := range sections {
+		if name == s.File || name == path.Base(s.File) || name == path.Dir(s.File) || Slug(name) == s.Topic() {
+			found = append(found, s)
+		}
+	}
+	if len(found) > 0 {
+		return found, nil
+	}
+
+	want := Slug(name)
+	for _, s := range sections {
+		if name == s.ID() || s.Name() == want {
+			return []Section{s}, nil
+		}
+	}
+	for _, s := range sections {
+		if want != "" && strings.Contains(s.Name(), want) {
+			found = append(found, s)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no section called %q, see list", name)
+	case 1:
+		return found, nil
+	}
+	var names []string
+	for _, s := range found {
+		names = append(names, s.Name())
+	}
+	sort.Strings(names)
+	return nil, fmt.Errorf("%q could be any of: %s", name, strings.Join(names, ", "))
+}
+
+// Capture runs a section and returns what it wrote to stdout.
+func Capture(run func()) string {
+	r, w, err := os.Pipe()
+	if err != nil {
+		panic(err)
+	}
+	done := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(r)
+		r.Close()
+		done <- b
+	}()
+
+	stdout := os.Stdout
+	os.Stdout = w
+	func() {
+		defer func() {
+			os.Stdout = stdout
+			w.Close()
+		}()
+		run()
+	}()
+	return string(<-done)
+}
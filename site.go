@@ -0,0 +1,288 @@
+// Static site
+//
+// The site command renders every section as a page in the style of
+// gobyexample.com, with the explanation beside the code it describes and the
+// output of running it underneath. Everything the pages need is written
+// alongside them, so the site works offline.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/scanner"
+	"go/token"
+	"html"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/omussell/go-by-example/section"
+)
+
+// A page is a section as shown on the site.
+type page struct {
+	Section    section.Section
+	File       string // Page file name
+	Segments   []htmlSegment
+	Output     string
+	Prev, Next *page
+}
+
+type htmlSegment struct {
+	Doc  []htmlParagraph
+	Code template.HTML
+}
+
+type htmlParagraph struct {
+	Text string
+	Pre  []string
+}
+
+// pageFile is the name of the page for a section, e.g. "basics-loops.html".
+func pageFile(s section.Section) string {
+	return s.Topic() + "-" + s.Name() + ".html"
+}
+
+// newPage renders the code of a section, followed by the declarations from
+// its package that it uses, and runs it to capture its output.
+func newPage(s section.Section) (*page, error) {
+	ss, err := source(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &page{Section: s, File: pageFile(s)}
+	texts := []string{ss.slice(ss.fn.Pos(), ss.fn.End())}
+	for _, d := range ss.uses() {
+		texts = append(texts, ss.text(d))
+	}
+	for _, t := range texts {
+		for _, seg := range segments(t) {
+			hs := htmlSegment{Code: highlight(seg.code)}
+			for _, para := range paragraphs(seg.doc) {
+				hs.Doc = append(hs.Doc, htmlParagraph{para.text, para.pre})
+			}
+			p.Segments = append(p.Segments, hs)
+		}
+	}
+	p.Output = capture(s)
+	return p, nil
+}
+
+// highlight marks up Go code for the stylesheet.
+func highlight(code string) template.HTML {
+	var b strings.Builder
+	classify(code, func(text, class string) {
+		if class == "" {
+			b.WriteString(html.EscapeString(text))
+			return
+		}
+		fmt.Fprintf(&b, `<span class="%s">%s</span>`, class, html.EscapeString(text))
+	})
+	return template.HTML(b.String())
+}
+
+// classify splits Go code into pieces and calls fn with each piece and its
+// class in the stylesheet, or "" for plain text. It uses the same scanner as
+// the compiler, so anything that parses is coloured correctly.
+func classify(code string, fn func(text, class string)) {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(code))
+	var s scanner.Scanner
+	s.Init(file, []byte(code), nil, scanner.ScanComments)
+
+	last := 0
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			continue // Inserted automatically, not in the source
+		}
+		text := lit
+		if text == "" {
+			text = tok.String()
+		}
+		start := file.Offset(pos)
+		if start > last {
+			fn(code[last:start], "")
+		}
+		last = start + len(text)
+
+		class := ""
+		switch {
+		case tok.IsKeyword():
+			class = "kw"
+		case tok == token.STRING || tok == token.CHAR:
+			class = "str"
+		case tok == token.INT || tok == token.FLOAT || tok == token.IMAG:
+			class = "num"
+		case tok == token.COMMENT:
+			class = "com"
+		case tok == token.IDENT && predeclared[lit]:
+			class = "pre"
+		}
+		fn(code[start:last], class)
+	}
+	if last < len(code) {
+		fn(code[last:], "")
+	}
+}
+
+var predeclared = map[string]bool{}
+
+func init() {
+	for _, name := range strings.Fields(`
+		bool byte complex64 complex128 error float32 float64 int int8 int16
+		int32 int64 rune string uint uint8 uint16 uint32 uint64 uintptr
+		true false iota nil
+		append cap close complex copy delete imag len make new panic print
+		println real recover`) {
+		predeclared[name] = true
+	}
+}
+
+// A topicIndex is the list of pages for one topic file, on the index page.
+type topicIndex struct {
+	Topic, File string
+	Pages       []*page
+}
+
+func siteCmd(args []string) error {
+	fs := flag.NewFlagSet("site", flag.ContinueOnError)
+	dir := fs.String("o", "site", "directory to write the site to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+	}
+
+	var pages []*page
+	var topics []*topicIndex
+	for _, s := range section.All() {
+		p, err := newPage(s)
+		if err != nil {
+			return err
+		}
+		if n := len(pages); n > 0 {
+			p.Prev, pages[n-1].Next = pages[n-1], p
+		}
+		pages = append(pages, p)
+		if n := len(topics); n == 0 || topics[n-1].File != s.File {
+			topics = append(topics, &topicIndex{Topic: s.Topic(), File: s.File})
+		}
+		t := topics[len(topics)-1]
+		t.Pages = append(t.Pages, p)
+	}
+
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(*dir, "site.css"), []byte(siteCSS), 0o644); err != nil {
+		return err
+	}
+	if err := writeTemplate(filepath.Join(*dir, "index.html"), "index", topics); err != nil {
+		return err
+	}
+	for _, p := range pages {
+		if err := writeTemplate(filepath.Join(*dir, p.File), "page", p); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Wrote %d pages to %s\n", len(pages)+1, filepath.Join(*dir, "index.html"))
+	return nil
+}
+
+func writeTemplate(name, tmpl string, data interface{}) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := siteTemplates.ExecuteTemplate(f, tmpl, data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+var siteTemplates = template.Must(template.New("").Parse(`
+{{define "head"}}<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.}} - Go by Example</title>
+<link rel="stylesheet" href="site.css">
+</head>
+<body>
+<div class="page">
+{{end}}
+
+{{define "index"}}{{template "head" "Index"}}
+<h1>Go by Example</h1>
+<p>Summarised versions of the <a href="https://gobyexample.com/">gobyexample</a> pages.</p>
+{{range .}}
+<h2>{{.Topic}} <small>{{.File}}</small></h2>
+<ul>
+{{range .Pages}}<li><a href="{{.File}}">{{.Section.Title}}</a></li>
+{{end}}</ul>
+{{end}}
+</div>
+</body>
+</html>
+{{end}}
+
+{{define "page"}}{{template "head" .Section.Title}}
+<h1><a href="index.html">Go by Example</a>: {{.Section.Title}}</h1>
+<table>
+{{range .Segments}}<tr>
+<td class="docs">{{range .Doc}}{{if .Text}}<p>{{.Text}}</p>{{end}}{{if .Pre}}<pre>{{range .Pre}}{{.}}
+{{end}}</pre>{{end}}{{end}}</td>
+<td class="code{{if not .Code}} empty{{end}}"><pre>{{.Code}}</pre></td>
+</tr>
+{{end}}<tr>
+<td class="docs"><p>Running it from {{.Section.File}} prints:</p></td>
+<td class="code output"><pre><span class="prompt">$ go run . run {{.Section.ID}}</span>
+{{.Output}}</pre></td>
+</tr>
+</table>
+<p class="next">
+{{with .Prev}}<a href="{{.File}}">&larr; {{.Section.Title}}</a>{{end}}
+{{with .Next}}<a class="right" href="{{.File}}">{{.Section.Title}} &rarr;</a>{{end}}
+</p>
+</div>
+</body>
+</html>
+{{end}}
+`))
+
+const siteCSS = `body {
+  margin: 0;
+  font-family: Georgia, serif;
+  font-size: 16px;
+  line-height: 20px;
+  color: #252519;
+}
+a, a:visited { color: #252519; }
+.page { max-width: 960px; margin: 0 auto; padding: 20px; }
+h1 { font-weight: normal; }
+h2 small { color: #808080; font-size: 14px; font-weight: normal; }
+table { border-spacing: 0; border-collapse: collapse; width: 100%; }
+td { vertical-align: top; padding: 0 15px; }
+td.docs { width: 40%; text-align: left; }
+td.code { background: #f0f0f0; }
+td.code.empty { background: none; }
+td.output { background: #e8e8e0; }
+pre, code { font-family: Menlo, Monaco, Consolas, "Courier New", monospace; font-size: 14px; }
+pre { margin: 0; padding: 10px 0; white-space: pre-wrap; tab-size: 4; }
+td.docs pre { background: #f8f8f8; padding: 5px; }
+.kw { color: #954121; font-weight: bold; }
+.str { color: #219161; }
+.num { color: #19469d; }
+.com { color: #808080; font-style: italic; }
+.pre { color: #7a3e9d; }
+.prompt { color: #808080; }
+p.next { margin-top: 30px; overflow: hidden; }
+p.next .right { float: right; }
+`
@@ -1,15 +1,22 @@
 // Sorting
 // Custom Sorting
-package main
+package sorting
 
 import (
 	"fmt"
 	"sort"
+
+	"github.com/omussell/go-by-example/section"
 )
 
-func commonFunctions() {
+// Sections are the examples in this file, in reading order.
+var Sections = []section.Section{
+	{Title: "Sorting", Run: Sorting},
+	{Title: "Custom Sorting", Run: CustomSorting},
+}
 
-	// Sorting
+// Sorting
+func Sorting() {
 	/*
 	   Sorting of built in types
 
@@ -25,15 +32,13 @@ func commonFunctions() {
 
 	a := sort.IntsAreSorted(ints)
 	fmt.Println("Sorted:", a) // Prints Sorted: true. Check if a slice is already in sorted order.
+}
 
-	/*
-	   Custom sorting
-	*/
-
+// Custom Sorting
+func CustomSorting() {
 	fruits := []string{"peach", "banana", "kiwi"}
 	sort.Sort(byLength(fruits)) // Convert fruits slice to byLength, then use sort.Sort on that typed slice
 	fmt.Println(fruits)         // Prints [kiwi peach banana]
-
 }
 
 type byLength []string
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/omussell/go-by-example/section"
+)
+
+// The topic packages are embedded so the examples can be inspected from the
+// compiled binary as well as from a checkout, along with the packages they
+// import from this module.
+//
+//go:embed basics/*.go collections/*.go advanced/*.go sorting/*.go errors/*.go async/*.go data/*.go times/*.go
+//go:embed clock/*.go cron/*.go
+var sources embed.FS
+
+// A sectionSource is the parsed function that runs a section, along with the
+// rest of its package.
+type sectionSource struct {
+	fset  *token.FileSet
+	files []*ast.File
+	src   map[string][]byte // Keyed by file name
+	file  *ast.File         // The topic file
+	fn    *ast.FuncDecl
+}
+
+// source finds the function that runs s in its topic file.
+func source(s section.Section) (*sectionSource, error) {
+	name := runtime.FuncForPC(reflect.ValueOf(s.Run).Pointer()).Name()
+	name = name[strings.LastIndex(name, ".")+1:]
+
+	ss := &sectionSource{fset: token.NewFileSet(), src: map[string][]byte{}}
+	entries, err := sources.ReadDir(path.Dir(s.File))
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		filename := path.Join(path.Dir(s.File), e.Name())
+		if strings.HasSuffix(filename, "_test.go") {
+			continue
+		}
+		src, err := sources.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(ss.fset, filename, src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		ss.files = append(ss.files, f)
+		ss.src[filename] = src
+		if filename == s.File {
+			ss.file = f
+		}
+	}
+	if ss.file == nil {
+		return nil, fmt.Errorf("%s is not embedded", s.File)
+	}
+
+	for _, d := range ss.file.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == name {
+			ss.fn = fn
+			return ss, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: no function %s for section %q", s.File, name, s.Title)
+}
+
+// comments returns the comments inside the section function, in source order.
+func (ss *sectionSource) comments() []*ast.Comment {
+	var cs []*ast.Comment
+	for _, g := range ss.file.Comments {
+		if g.Pos() < ss.fn.Body.Lbrace || g.End() > ss.fn.Body.Rbrace {
+			continue
+		}
+		cs = append(cs, g.List...)
+	}
+	return cs
+}
+
+// uses returns the declarations in the package that the section function
+// needs, directly or indirectly, in the order they appear. Types come with
+// their methods.
+func (ss *sectionSource) uses() []ast.Decl {
+	named := map[string]ast.Decl{}
+	methods := map[string][]ast.Decl{}
+	top := map[interface{}]bool{}
+	for _, f := range ss.files {
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				top[d] = true
+				if d.Recv == nil {
+					named[d.Name.Name] = d
+					continue
+				}
+				t := d.Recv.List[0].Type
+				if star, ok := t.(*ast.StarExpr); ok {
+					t = star.X
+				}
+				if id, ok := t.(*ast.Ident); ok {
+					methods[id.Name] = append(methods[id.Name], d)
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					top[spec] = true
+					switch spec := spec.(type) {
+					case *ast.TypeSpec:
+						named[spec.Name.Name] = d
+					case *ast.ValueSpec:
+						for _, n := range spec.Names {
+							named[n.Name] = d
+						}
+					}
+				}
+			}
+		}
+	}
+
+	used := map[ast.Decl]bool{ss.fn: true}
+	queue := []ast.Decl{ss.fn}
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+		ast.Inspect(d, func(n ast.Node) bool {
+			id, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			// Identifiers resolved to something declared inside a function
+			// are local, and can only shadow package level names.
+			if id.Obj != nil && !top[id.Obj.Decl] {
+				return true
+			}
+			dep, ok := named[id.Name]
+			if !ok || used[dep] {
+				return true
+			}
+			used[dep] = true
+			queue = append(queue, dep)
+			if g, ok := dep.(*ast.GenDecl); ok && g.Tok == token.TYPE {
+				for _, spec := range g.Specs {
+					for _, m := range methods[spec.(*ast.TypeSpec).Name.Name] {
+						if !used[m] {
+							used[m] = true
+							queue = append(queue, m)
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	// The topic file comes first, as that's where the section is.
+	files := []*ast.File{ss.file}
+	for _, f := range ss.files {
+		if f != ss.file {
+			files = append(files, f)
+		}
+	}
+	var decls []ast.Decl
+	for _, f := range files {
+		for _, d := range f.Decls {
+			if used[d] && d != ss.fn {
+				decls = append(decls, d)
+			}
+		}
+	}
+	return decls
+}
+
+// text returns the source of a declaration, including its doc comment.
+func (ss *sectionSource) text(d ast.Decl) string {
+	start := d.Pos()
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		if d.Doc != nil {
+			start = d.Doc.Pos()
+		}
+	case *ast.GenDecl:
+		if d.Doc != nil {
+			start = d.Doc.Pos()
+		}
+	}
+	return ss.slice(start, d.End())
+}
+
+// slice returns the source between two positions in the same file.
+func (ss *sectionSource) slice(start, end token.Pos) string {
+	f := ss.fset.File(start)
+	return string(ss.src[f.Name()][f.Offset(start):f.Offset(end)])
+}
+
+// A segment pairs an explanation with the code it describes, like the two
+// columns of gobyexample.com.
+type segment struct {
+	doc  string // Comment text without the comment markers, blank lines separate paragraphs
+	code string
+}
+
+// segments splits source code into segments. Comments on lines of their own
+// are the explanation for the code that follows them, comments at the end of
+// a line stay with the code.
+func segments(src string) []segment {
+	var segs []segment
+	var doc, code, block []string
+	flush := func() {
+		for len(code) > 0 && strings.TrimSpace(code[len(code)-1]) == "" {
+			code = code[:len(code)-1]
+		}
+		for len(doc) > 0 && doc[len(doc)-1] == "" {
+			doc = doc[:len(doc)-1]
+		}
+		if len(doc) > 0 || len(code) > 0 {
+			segs = append(segs, segment{strings.Join(doc, "\n"), strings.Join(code, "\n")})
+		}
+		doc, code = nil, nil
+	}
+
+	inBlock := false
+	for _, line := range strings.Split(src, "\n") {
+		t := strings.TrimSpace(line)
+		switch {
+		case inBlock:
+			if i := strings.Index(line, "*/"); i >= 0 {
+				block = append(block, line[:i])
+				doc = append(doc, dedent(block)...)
+				block, inBlock = nil, false
+				continue
+			}
+			block = append(block, line)
+		case strings.HasPrefix(t, "//"):
+			if len(code) > 0 {
+				flush()
+			}
+			doc = append(doc, strings.TrimSpace(strings.TrimPrefix(t, "//")))
+		case strings.HasPrefix(t, "/*"):
+			if len(code) > 0 {
+				flush()
+			}
+			rest := t[2:]
+			if i := strings.Index(rest, "*/"); i >= 0 {
+				doc = append(doc, strings.TrimSpace(rest[:i]))
+				continue
+			}
+			inBlock = true
+			if strings.TrimSpace(rest) != "" {
+				doc = append(doc, strings.TrimSpace(rest))
+			}
+		case t == "" && len(code) == 0:
+			if len(doc) > 0 && doc[len(doc)-1] != "" {
+				doc = append(doc, "")
+			}
+		default:
+			code = append(code, line)
+		}
+	}
+	flush()
+	return segs
+}
+
+// dedent removes the indentation shared by all the lines, along with leading
+// and trailing blank lines.
+func dedent(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	indent, seen := "", false
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		lead := l[:len(l)-len(strings.TrimLeft(l, " \t"))]
+		if !seen || len(lead) < len(indent) {
+			indent, seen = lead, true
+		}
+	}
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = strings.TrimRight(strings.TrimPrefix(l, indent), " \t")
+	}
+	return out
+}
+
+// A paragraph of explanation. The output listed in a Prints comment keeps
+// its lines, in pre.
+type paragraph struct {
+	text string
+	pre  []string
+}
+
+// paragraphs splits the explanation of a segment into paragraphs.
+func paragraphs(doc string) []paragraph {
+	if doc == "" {
+		return nil
+	}
+	var ps []paragraph
+	for _, p := range strings.Split(doc, "\n\n") {
+		lines := strings.Split(strings.Trim(p, "\n"), "\n")
+		if len(lines) > 1 && printsComment.MatchString(lines[0]) {
+			ps = append(ps, paragraph{text: lines[0], pre: lines[1:]})
+			continue
+		}
+		ps = append(ps, paragraph{text: strings.Join(lines, " ")})
+	}
+	return ps
+}
@@ -0,0 +1,20 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+var errNoTerminal = errors.New("the browser needs a Unix terminal")
+
+func rawMode(f *os.File) (func(), error) {
+	return nil, errNoTerminal
+}
+
+func termSize(f *os.File) (cols, rows int, err error) {
+	return 0, 0, errNoTerminal
+}
+
+func notifyResize(c chan<- os.Signal) {}
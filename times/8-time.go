@@ -0,0 +1,550 @@
+// Time
+// Comparing Times
+// Time Arithmetic
+// Monotonic Clock
+// Time Zones
+// Time Formatting
+// Time Parsing
+// Detecting Layouts
+// Epoch
+// Cron Schedules
+// Scheduling Jobs
+// Durations
+// Humanising Durations
+// Business Days
+package times
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // Embeds the time zone database, so locations load wherever the binary is copied
+
+	"github.com/omussell/go-by-example/clock"
+	"github.com/omussell/go-by-example/cron"
+	"github.com/omussell/go-by-example/section"
+)
+
+// Sections are the examples in this file, in reading order.
+var Sections = []section.Section{
+	{Title: "Time", Run: Time},
+	{Title: "Comparing Times", Run: ComparingTimes},
+	{Title: "Time Arithmetic", Run: TimeArithmetic},
+	{Title: "Monotonic Clock", Run: MonotonicClock},
+	{Title: "Time Zones", Run: TimeZones},
+	{Title: "Time Formatting", Run: TimeFormatting},
+	{Title: "Time Parsing", Run: TimeParsing},
+	{Title: "Detecting Layouts", Run: DetectingLayouts},
+	{Title: "Epoch", Run: Epoch},
+	{Title: "Cron Schedules", Run: CronSchedules},
+	{Title: "Scheduling Jobs", Run: SchedulingJobs},
+	{Title: "Durations", Run: Durations},
+	{Title: "Humanising Durations", Run: HumanisingDurations},
+	{Title: "Business Days", Run: BusinessDays},
+}
+
+// Time
+func Time() {
+	/*
+	   The examples get the current time from clock.Now, which is time.Now unless the runner is pretending it is some other time. When they are checked it is always 23:00 UTC on the 10th of November 2009.
+	*/
+	p := fmt.Println
+	now := clock.Now()
+	p(now) // Prints 2009-11-10 23:00:00 +0000 UTC
+
+	/*
+	   time.Date builds a time from its parts, always in a location, here UTC.
+	*/
+	then := time.Date(2009, time.November, 17, 20, 34, 58, 651387237, time.UTC)
+	p(then) // Prints 2009-11-17 20:34:58.651387237 +0000 UTC
+
+	p(then.Year(), then.Month(), then.Day())                        // Prints 2009 November 17
+	p(then.Hour(), then.Minute(), then.Second(), then.Nanosecond()) // Prints 20 34 58 651387237
+	p(then.Location(), then.Weekday(), then.YearDay())              // Prints UTC Tuesday 321
+	year, week := then.ISOWeek()
+	p(year, week)          // Prints 2009 47
+	y, m, d := then.Date() // Date and Clock get several parts at once
+	h, min, s := then.Clock()
+	p(y, m, d, h, min, s) // Prints 2009 November 17 20 34 58
+
+	/*
+	   Parts that are out of range roll over into the next one, so there is no need to work out how many days are in a month.
+	*/
+	p(time.Date(2023, time.February, 29, 0, 0, 0, 0, time.UTC))    // Prints 2023-03-01 00:00:00 +0000 UTC
+	p(time.Date(2023, time.December, 31+1, 25, 0, 0, 0, time.UTC)) // Prints 2024-01-02 01:00:00 +0000 UTC
+	p(time.Date(2024, time.March, 0, 0, 0, 0, 0, time.UTC).Day())  // Prints 29. Day 0 is the last day of the month before, so this is how many days February has
+
+	/*
+	   The zero Time is the start of year 1, and IsZero checks for it, as a way to say a time hasn't been set.
+	*/
+	var zero time.Time
+	p(zero, zero.IsZero()) // Prints 0001-01-01 00:00:00 +0000 UTC true
+}
+
+// Comparing Times
+func ComparingTimes() {
+	p := fmt.Println
+	then := time.Date(2009, time.November, 17, 20, 34, 58, 651387237, time.UTC)
+	now := clock.Now()
+
+	p(then.Before(now), then.After(now), then.Equal(now)) // Prints false true false
+
+	/*
+	   A Time holds a location as well as the instant, so the same instant in two locations isn't == but is Equal. Always compare times with Equal, and don't use them as map keys without converting them to one location, or to a number.
+	*/
+	tokyo := time.FixedZone("JST", 9*60*60)
+	inTokyo := then.In(tokyo)
+	p(inTokyo)                              // Prints 2009-11-18 05:34:58.651387237 +0900 JST
+	p(inTokyo == then, inTokyo.Equal(then)) // Prints false true
+	p(inTokyo.UTC() == then)                // Prints true
+
+	/*
+	   Sorting times, or finding the latest, needs Before and After too.
+	*/
+	times := []time.Time{then, now, inTokyo.Add(-time.Hour)}
+	latest := times[0]
+	for _, t := range times[1:] {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	p(latest) // Prints 2009-11-17 20:34:58.651387237 +0000 UTC
+}
+
+// Time Arithmetic
+func TimeArithmetic() {
+	p := fmt.Println
+	then := time.Date(2009, time.November, 17, 20, 34, 58, 651387237, time.UTC)
+	now := clock.Now()
+
+	/*
+	   Sub gives the Duration between two times, a number of nanoseconds, which can be given in other units.
+	*/
+	diff := then.Sub(now)
+	p(diff)                                              // Prints 165h34m58.651387237s
+	p(diff.Hours(), diff.Minutes())                      // Prints 165.58295871867693 9934.977523120617
+	p(diff.Round(time.Hour), diff.Truncate(time.Minute)) // Prints 166h0m0s 165h34m0s
+
+	/*
+	   Add moves a time on by a Duration, or back by a negative one. Since and Until are short for Now().Sub(t) and t.Sub(Now()); clock.Since uses the runner's clock.
+	*/
+	p(then.Add(diff))             // Prints 2009-11-24 18:09:57.302774474 +0000 UTC
+	p(then.Add(-diff))            // Prints 2009-11-10 23:00:00 +0000 UTC
+	p(then.Add(90 * time.Minute)) // Prints 2009-11-17 22:04:58.651387237 +0000 UTC
+	p(clock.Since(then))          // Prints -165h34m58.651387237s
+
+	/*
+	   AddDate adds years, months and days, which aren't a fixed length, so they aren't Durations. It rolls over like time.Date does, so a month after the 31st of January isn't in February.
+	*/
+	p(then.AddDate(0, 1, 0)) // Prints 2009-12-17 20:34:58.651387237 +0000 UTC
+	jan31 := time.Date(2023, time.January, 31, 0, 0, 0, 0, time.UTC)
+	p(jan31.AddDate(0, 1, 0)) // Prints 2023-03-03 00:00:00 +0000 UTC
+
+	/*
+	   Truncate and Round round a time down, or to the nearest, multiple of a Duration since the zero time. They work in UTC, so to get the start of a day somewhere else, use time.Date instead.
+	*/
+	p(then.Truncate(time.Hour)) // Prints 2009-11-17 20:00:00 +0000 UTC
+	p(then.Round(time.Hour))    // Prints 2009-11-17 21:00:00 +0000 UTC
+	p(then.Round(time.Second))  // Prints 2009-11-17 20:34:59 +0000 UTC
+	y, m, d := then.Date()
+	p(time.Date(y, m, d, 0, 0, 0, 0, then.Location())) // Prints 2009-11-17 00:00:00 +0000 UTC
+}
+
+// Monotonic Clock
+func MonotonicClock() {
+	/*
+	   The wall clock can jump, when it is set or corrected by NTP, so a time taken from it to measure how long something took could be out, or even negative. time.Now also reads the monotonic clock, which only goes forwards, and Sub and Since use that when both times have it. The String of a time shows it at the end, as m=.
+	*/
+	start := time.Now()
+	fmt.Println(strings.Contains(start.String(), " m=")) // Prints true
+
+	elapsed := time.Since(start)
+	fmt.Println(elapsed >= 0) // Prints true
+
+	/*
+	   Anything that changes the time other than Add, like Round(0), In, UTC or Truncate, removes the monotonic reading, as do times that are built or parsed. Round(0) is the way to remove it deliberately, for comparing times with ==.
+	*/
+	fmt.Println(strings.Contains(start.Round(0).String(), " m="))       // Prints false
+	fmt.Println(strings.Contains(start.Add(time.Hour).String(), " m=")) // Prints true
+	fmt.Println(strings.Contains(start.UTC().String(), " m="))          // Prints false
+	fmt.Println(start == start.Round(0), start.Equal(start.Round(0)))   // Prints false true
+
+	/*
+	   The monotonic reading only means something within one run of the program, so it isn't kept when a time is marshalled to JSON or sent anywhere else.
+	*/
+	b, _ := start.MarshalText()
+	var back time.Time
+	back.UnmarshalText(b)
+	fmt.Println(strings.Contains(back.String(), " m="), back.Equal(start)) // Prints false true
+}
+
+// Time Zones
+func TimeZones() {
+	/*
+	   LoadLocation finds a location by its name in the IANA time zone database. The database is usually read from the system, but importing time/tzdata embeds it in the binary, so this works in a container or on a computer without one too.
+	*/
+	p := fmt.Println
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		panic(err)
+	}
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	_, err = time.LoadLocation("Mars/Olympus_Mons")
+	p(err) // Prints unknown time zone Mars/Olympus_Mons
+
+	/*
+	   In shows the same instant in another location.
+	*/
+	t := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	p(t.In(ny))                  // Prints 2009-11-10 18:00:00 -0500 EST
+	p(t.In(tokyo))               // Prints 2009-11-11 08:00:00 +0900 JST
+	p(t.In(time.Local).Equal(t)) // Prints true. Local is the zone of the computer, so what it shows depends where it runs
+
+	/*
+	   A location is more than an offset. It has the history of the zone's rules, including daylight saving time, so the offset depends on the date.
+	*/
+	winter := time.Date(2021, time.January, 15, 12, 0, 0, 0, ny)
+	summer := time.Date(2021, time.July, 15, 12, 0, 0, 0, ny)
+	p(winter, summer) // Prints 2021-01-15 12:00:00 -0500 EST 2021-07-15 12:00:00 -0400 EDT
+	name, offset := summer.Zone()
+	p(name, offset/3600) // Prints EDT -4
+
+	/*
+	   Days aren't always 24 hours long there either. On the 14th of March 2021 New York's clocks went from 02:00 to 03:00, so AddDate, which keeps the clock time, and Add, which adds exact hours, give different answers.
+	*/
+	before := time.Date(2021, time.March, 13, 12, 0, 0, 0, ny)
+	p(before.AddDate(0, 0, 1))             // Prints 2021-03-14 12:00:00 -0400 EDT
+	p(before.Add(24 * time.Hour))          // Prints 2021-03-14 13:00:00 -0400 EDT
+	p(before.AddDate(0, 0, 1).Sub(before)) // Prints 23h0m0s
+
+	/*
+	   A clock time that was skipped when the clocks went forward doesn't exist, and one from when they went back happened twice. time.Date doesn't promise which offset it uses for either, so times near a change are best built in UTC, or checked.
+	*/
+	p(time.Date(2021, time.March, 14, 2, 30, 0, 0, ny))   // Prints 2021-03-14 01:30:00 -0500 EST
+	p(time.Date(2021, time.November, 7, 1, 30, 0, 0, ny)) // Prints 2021-11-07 01:30:00 -0400 EDT
+}
+
+// Time Formatting
+func TimeFormatting() {
+	/*
+	   Times are formatted by writing out how the reference time, Mon Jan 2 15:04:05 MST 2006, would look. Each part of it is a different number, 1 2 3 4 5 6 -7, so the layout can say where each part goes and how. The time package has constants for common layouts, like RFC3339.
+	*/
+	p := fmt.Println
+	t := time.Date(2009, time.November, 10, 23, 4, 5, 123456789, time.UTC)
+	p(t.Format(time.RFC3339))                       // Prints 2009-11-10T23:04:05Z
+	p(t.Format(time.RFC3339Nano))                   // Prints 2009-11-10T23:04:05.123456789Z
+	p(t.Format(time.Kitchen))                       // Prints 11:04PM
+	p(t.Format("Mon Jan _2 15:04:05 2006"))         // Prints Tue Nov 10 23:04:05 2009
+	p(t.Format("2006-01-02T15:04:05.000000-07:00")) // Prints 2009-11-10T23:04:05.123456+00:00
+	p(t.Format("Monday, 2 January 2006 at 3:04pm")) // Prints Tuesday, 10 November 2009 at 11:04pm
+	p(t.Format("06/1/2 15h04"))                     // Prints 09/11/10 23h04. Short forms leave out the leading zeros
+
+	/*
+	   Fractional seconds are zeros or nines after a dot or comma: zeros always print that many digits, nines leave off trailing zeros.
+	*/
+	p(t.Truncate(time.Millisecond).Format("15:04:05.000000")) // Prints 23:04:05.123000
+	p(t.Truncate(time.Millisecond).Format("15:04:05.999999")) // Prints 23:04:05.123
+
+	/*
+	   Anything that isn't one of the parts is printed as it is, so using a number that isn't the reference time goes wrong quietly. Here the 2s in 2021 are each the day, and the 1 the month.
+	*/
+	p(t.Format("2021-01-02")) // Prints 101011-11-10
+
+	/*
+	   The parts can also be printed with Printf, for formats a layout can't give.
+	*/
+	fmt.Printf("%d-%02d-%02d day %d of the year\n", t.Year(), t.Month(), t.Day(), t.YearDay()) // Prints 2009-11-10 day 314 of the year
+}
+
+// Time Parsing
+func TimeParsing() {
+	/*
+	   time.Parse uses the same layouts to read a time.
+	*/
+	p := fmt.Println
+	t, err := time.Parse(time.RFC3339, "2012-11-01T22:08:41+00:00")
+	p(t, err) // Prints 2012-11-01 22:08:41 +0000 UTC <nil>
+	t, _ = time.Parse("3 04 PM", "8 41 PM")
+	p(t) // Prints 0000-01-01 20:41:00 +0000 UTC. Parts missing from the layout are zero, so this is on the first day of year 0
+
+	/*
+	   A time with no zone in it is taken to be UTC by Parse, which is usually wrong for times people type in. ParseInLocation takes it to be in the location given instead.
+	*/
+	ny, _ := time.LoadLocation("America/New_York")
+	const local = "2009-11-10 18:00"
+	t, _ = time.Parse("2006-01-02 15:04", local)
+	p(t) // Prints 2009-11-10 18:00:00 +0000 UTC
+	t, _ = time.ParseInLocation("2006-01-02 15:04", local, ny)
+	p(t, t.UTC()) // Prints 2009-11-10 18:00:00 -0500 EST 2009-11-10 23:00:00 +0000 UTC
+
+	/*
+	   Zone abbreviations are a trap. They aren't unique, CST is in America, China and Cuba, so Parse only knows the ones of the location it is parsing in, time.Local for Parse. Any other abbreviation gets a made up zone with that name and an offset of zero, and no error.
+	*/
+	t, _ = time.ParseInLocation(time.RFC1123, "Tue, 10 Nov 2009 18:00:00 EST", ny)
+	p(t, t.UTC()) // Prints 2009-11-10 18:00:00 -0500 EST 2009-11-10 23:00:00 +0000 UTC
+	t, _ = time.ParseInLocation(time.RFC1123, "Tue, 10 Nov 2009 15:00:00 PST", ny)
+	p(t, t.UTC()) // Prints 2009-11-10 15:00:00 +0000 PST 2009-11-10 15:00:00 +0000 UTC
+	/*
+	   So prefer numeric offsets, like RFC1123Z and RFC3339 have, in anything a program writes.
+	*/
+
+	/*
+	   Input that doesn't match is a *time.ParseError, saying which part of the layout didn't match which part of the value.
+	*/
+	_, err = time.Parse(time.RFC3339, "2009-11-10 23:00:00Z")
+	p(err) // Prints parsing time "2009-11-10 23:00:00Z" as "2006-01-02T15:04:05Z07:00": cannot parse " 23:00:00Z" as "T"
+	var pe *time.ParseError
+	if errors.As(err, &pe) {
+		fmt.Printf("%q %q\n", pe.LayoutElem, pe.ValueElem) // Prints "T" " 23:00:00Z"
+	}
+	_, err = time.Parse("2006-01-02", "2009-02-30")
+	p(err) // Prints parsing time "2009-02-30": day out of range
+	if errors.As(err, &pe) {
+		fmt.Printf("%q %q %q\n", pe.LayoutElem, pe.ValueElem, pe.Message) // Prints "" "" ": day out of range". Message is set instead when the value is out of range
+	}
+}
+
+// Detecting Layouts
+func DetectingLayouts() {
+	/*
+	   Logs from different systems write timestamps in different layouts. ParseAny tries each of a list of known layouts until one works, and says which it was. times/layouts_test.go has a timestamp in each layout, some with stray spaces or a comma before the fraction, and syslog ones read in leap years and others.
+	*/
+	ny, _ := time.LoadLocation("America/New_York")
+	now := clock.Now()
+	t, layout, err := ParseAny("Nov 10 18:00:00", ny, now)
+	fmt.Println(t, layout, err) // Prints 2009-11-10 18:00:00 -0500 EST syslog <nil>
+	_, _, err = ParseAny("10 Nov 2009", ny, now)
+	fmt.Println(err) // Prints "10 Nov 2009" is not a timestamp in any known layout
+
+	/*
+	   Syslog leaves the year out, so it is taken to be this year, which is an error when this year doesn't have the date.
+	*/
+	_, _, err = ParseAny("Feb 29 12:00:00", ny, now)
+	fmt.Println(err) // Prints "Feb 29 12:00:00" is not a date in 2009
+}
+
+// Epoch
+func Epoch() {
+	/*
+	   A Unix timestamp is the number of seconds, or milliseconds or nanoseconds, since the Unix epoch, midnight UTC on the 1st of January 1970. Unix, UnixMilli, UnixMicro and UnixNano give them. They are the same wherever the time is, so they are a good way to store and send times.
+	*/
+	p := fmt.Println
+	now := clock.Now()
+	p(now)             // Prints 2009-11-10 23:00:00 +0000 UTC
+	p(now.Unix())      // Prints 1257894000
+	p(now.UnixMilli()) // Prints 1257894000000
+	p(now.UnixMicro()) // Prints 1257894000000000
+	p(now.UnixNano())  // Prints 1257894000000000000
+
+	/*
+	   time.Unix, UnixMilli and UnixMicro turn them back into times, in the Local zone, so In or UTC is needed to show them in a particular one. time.Unix takes seconds and nanoseconds, and either can be used on its own.
+	*/
+	p(time.Unix(now.Unix(), 0).UTC())         // Prints 2009-11-10 23:00:00 +0000 UTC
+	p(time.UnixMilli(now.UnixMilli()).UTC())  // Prints 2009-11-10 23:00:00 +0000 UTC
+	p(time.Unix(0, now.UnixNano()).UTC())     // Prints 2009-11-10 23:00:00 +0000 UTC
+	p(time.Unix(1257894000, 123456789).UTC()) // Prints 2009-11-10 23:00:00.123456789 +0000 UTC
+	p(time.Unix(-1, 0).UTC())                 // Prints 1969-12-31 23:59:59 +0000 UTC. Before 1970 is negative
+
+	/*
+	   A timestamp in nanoseconds only fits in an int64 for the years 1678 to 2262. Outside them UnixNano is undefined, and gives a time that is wrong.
+	*/
+	far := time.Date(2300, time.January, 1, 0, 0, 0, 0, time.UTC)
+	p(far.Unix(), time.Unix(0, far.UnixNano()).UTC().Year()) // Prints 10413792000 1715. The seconds are right, but the nanoseconds wrapped around
+
+	/*
+	   A timestamp doesn't say what unit it is in, and different systems use different ones: seconds in Unix tools, milliseconds in JavaScript and Java, nanoseconds in Go. FromUnix works it out from its size, as the same time in a smaller unit is a thousand times bigger. The epoch command uses it to convert timestamps given to it.
+	*/
+	for _, n := range []int64{1257894000, 1257894000123, 1257894000123456, 1257894000123456789, 0, -86400, 1e11} {
+		t, unit := FromUnix(n)
+		fmt.Printf("%-20d %-4s %s\n", n, unit, t.UTC().Format(time.RFC3339Nano))
+	}
+	/*
+	   Prints:
+	   1257894000           1s   2009-11-10T23:00:00Z
+	   1257894000123        1ms  2009-11-10T23:00:00.123Z
+	   1257894000123456     1µs  2009-11-10T23:00:00.123456Z
+	   1257894000123456789  1ns  2009-11-10T23:00:00.123456789Z
+	   0                    1s   1970-01-01T00:00:00Z
+	   -86400               1s   1969-12-31T00:00:00Z
+	   100000000000         1ms  1973-03-03T09:46:40Z
+	*/
+}
+
+// Cron Schedules
+func CronSchedules() {
+	/*
+	   The cron package reads schedules written as cron expressions: minute, hour, day of month, month and day of week, each a value, a range, a list or * for any, optionally with a step. Next says when a schedule next fires after a time, and cron/cron_test.go checks it against all sorts of schedules, and the days New York's clocks changed.
+	*/
+	ny, _ := time.LoadLocation("America/New_York")
+	const layout = "Mon 2006-01-02 15:04 MST"
+	s, _ := cron.ParseInLocation("30 8,12 * * MON-FRI", ny)
+	t := clock.Now() // 18:00 on a Tuesday in New York
+	for i := 0; i < 4; i++ {
+		t = s.Next(t)
+		fmt.Println(t.Format(layout))
+	}
+	/*
+	   Prints:
+	   Wed 2009-11-11 08:30 EST
+	   Wed 2009-11-11 12:30 EST
+	   Thu 2009-11-12 08:30 EST
+	   Thu 2009-11-12 12:30 EST
+	*/
+
+	/*
+	   Daylight saving time changes are handled as most crons do. A time skipped when the clocks go forward doesn't happen, so a job at 02:30 doesn't run that night, and one at 01:30 only runs once when the clocks go back and 01:30 comes round twice.
+	*/
+	s, _ = cron.ParseInLocation("30 2 * * *", ny)
+	fmt.Println(s.Next(time.Date(2021, 3, 13, 12, 0, 0, 0, ny)).Format(layout)) // Prints Mon 2021-03-15 02:30 EDT
+	s, _ = cron.ParseInLocation("30 1 * * *", ny)
+	t = s.Next(time.Date(2021, 11, 6, 12, 0, 0, 0, ny))
+	fmt.Println(t.Format(layout), s.Next(t).Format(layout)) // Prints Sun 2021-11-07 01:30 EDT Mon 2021-11-08 01:30 EST
+
+	/*
+	   Expressions that can't be parsed say what is wrong with them.
+	*/
+	_, err := cron.Parse("0 17-9 * * *")
+	fmt.Println(err) // Prints cron: "0 17-9 * * *": hour field: range "17-9" goes backwards
+}
+
+// Scheduling Jobs
+func SchedulingJobs() {
+	/*
+	   A cron.Scheduler runs jobs in goroutines, each time their schedule fires. If a job is still running from last time when it is due again, it is skipped, so a slow job doesn't pile up runs of itself. Cancelling the context stops the scheduler, and the jobs get the context too, so they can stop what they are doing, like the worker in async/6-async.go, but with a way to be told to stop.
+
+	   Minutes are a long time to wait for an example, so this one makes its own virtual clock, where they pass in moments. Cron times are in the Local zone unless ParseInLocation says otherwise, so these are in UTC, as the clock is.
+	*/
+	defer clock.Set(clock.NewVirtual(clock.Now()))()
+	start := clock.Now()
+	fmt.Println("starting at", start.Format("15:04:05"))
+
+	var s cron.Scheduler
+	s.Skipped = func(name string, at time.Time) {
+		fmt.Println(name, "skipped at", at.Format("15:04"))
+	}
+
+	report, _ := cron.ParseInLocation("*/2 * * * *", time.UTC)
+	s.Add("report", report, func(ctx context.Context, at time.Time) {
+		fmt.Println("report started at", at.Format("15:04"))
+		select {
+		case <-clock.After(150 * time.Second): // Takes two and a half minutes, longer than the two between runs
+			fmt.Println("report finished at", clock.Now().Format("15:04:05"))
+		case <-ctx.Done():
+			fmt.Println("report cancelled at", clock.Now().Format("15:04:05"))
+		}
+	})
+	ping, _ := cron.ParseInLocation("1-59/4 * * * *", time.UTC)
+	s.Add("ping", ping, func(ctx context.Context, at time.Time) {
+		fmt.Println("ping at", at.Format("15:04"))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	clock.AfterFunc(7*time.Minute+30*time.Second, cancel) // context.WithTimeout would use the real clock
+	err := s.Run(ctx)
+	fmt.Println("stopped at", clock.Now().Format("15:04:05"), err)
+	/*
+	   Prints:
+	   starting at 23:00:00
+	   ping at 23:01
+	   report started at 23:02
+	   report skipped at 23:04
+	   report finished at 23:04:30
+	   ping at 23:05
+	   report started at 23:06
+	   report cancelled at 23:07:30
+	   stopped at 23:07:30 context canceled
+	*/
+}
+
+// Durations
+func Durations() {
+	/*
+	   A time.Duration is a number of nanoseconds, in an int64, so it can be up to about 292 years. ParseDuration reads the way String writes them, a sequence of numbers with units from ns to h, and there is no unit for days, as they aren't always 24 hours.
+	*/
+	d, err := time.ParseDuration("1h15m30.5s")
+	fmt.Println(d, err) // Prints 1h15m30.5s <nil>
+	var ds []string
+	for _, s := range []string{"300ms", "-1.5h", "90m", "1µs"} {
+		d, _ := time.ParseDuration(s)
+		ds = append(ds, d.String())
+	}
+	fmt.Println(strings.Join(ds, " ")) // Prints 300ms -1h30m0s 1h30m0s 1µs
+	_, err = time.ParseDuration("2d")
+	fmt.Println(err) // Prints time: unknown unit "d" in duration "2d"
+
+	/*
+	   Hours, Minutes and Seconds give a Duration in a unit as a float. Dividing by a unit gives a whole number, rounded down, but still as a Duration, so it needs converting to print as a number. A number has to be converted to a Duration to multiply it by one, unless it is a constant.
+	*/
+	fmt.Println(d.Hours(), d.Minutes(), d.Seconds())                // Prints 1.2584722222222222 75.50833333333334 4530.5
+	fmt.Println(int64(d/time.Minute), d/time.Minute, d%time.Minute) // Prints 75 75ns 30.5s
+	n := 3
+	fmt.Println(time.Duration(n)*time.Second, 3*time.Second) // Prints 3s 3s
+
+	/*
+	   String always writes hours, minutes and seconds, rather than days, and as many decimal places as it takes. Round and Truncate tidy it up, and for a clock like display, the parts can be worked out and printed with Printf.
+	*/
+	d = 50*time.Hour + 3*time.Minute + 7*time.Second + 123456789
+	fmt.Println(d, d.Round(time.Second), d.Truncate(time.Minute))                            // Prints 50h3m7.123456789s 50h3m7s 50h3m0s
+	fmt.Printf("%02d:%02d:%02d\n", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60) // Prints 50:03:07
+
+	/*
+	   parseDuration understands days and weeks too, as people write them in config files and on command lines, taking a day to be 24 hours. times/durations_test.go tests it, along with the other functions here.
+	*/
+	d, _ = parseDuration("1w2d12h")
+	fmt.Println(d) // Prints 228h0m0s
+	_, err = parseDuration("2y")
+	fmt.Println(err) // Prints unknown unit "y" in duration "2y"
+}
+
+// Humanising Durations
+func HumanisingDurations() {
+	/*
+	   People don't want to read 74h3m7.123456789s. humanise rounds a Duration to what a person would say, and relative says which way it is from now.
+	*/
+	d := 74*time.Hour + 3*time.Minute + 7*time.Second + 123456789
+	fmt.Println(d, humanise(d))                                         // Prints 74h3m7.123456789s 3 days
+	fmt.Println(humanise(2*time.Hour + 5*time.Minute + 40*time.Second)) // Prints 2h 6m. Rounded to the nearest minute
+
+	now := clock.Now()
+	var when []string
+	for _, d := range []time.Duration{-74 * time.Hour, 2*time.Hour + 5*time.Minute, -90 * time.Second, 100 * time.Millisecond} {
+		when = append(when, relative(now.Add(d), now))
+	}
+	fmt.Println(strings.Join(when, ", ")) // Prints 3 days ago, in 2h 5m, 1m 30s ago, now
+}
+
+// Business Days
+func BusinessDays() {
+	/*
+	   Working days skip weekends, which a switch on the Weekday can spot, as in basics, and holidays, which differ between countries, so a calendar is given its own list. These are England's for the end of 2021, when Christmas Day and Boxing Day fell at the weekend and the holidays moved to the Monday and Tuesday after.
+	*/
+	c, err := newCalendar("2021-12-27", "2021-12-28", "2022-01-03")
+	if err != nil {
+		panic(err)
+	}
+	date := func(s string) time.Time {
+		t, _ := time.Parse("2006-01-02 15:04", s)
+		return t
+	}
+	const layout = "Mon 2006-01-02 15:04"
+
+	friday := date("2021-12-24 17:30")
+	fmt.Println(c.isWorkday(friday), c.isWorkday(friday.AddDate(0, 0, 3)))             // Prints true false. Monday the 27th is a holiday
+	fmt.Println(c.addWorkdays(friday, 1).Format(layout))                               // Prints Wed 2021-12-29 17:30. Over the weekend and both holidays
+	fmt.Println(c.addWorkdays(friday, -5).Format(layout))                              // Prints Fri 2021-12-17 17:30
+	fmt.Println(c.workdaysBetween(date("2021-12-17 09:00"), date("2022-01-05 09:00"))) // Prints 10. Two weeks, less three holidays
+
+	/*
+	   Days are added with AddDate, so the time of day stays the same when the clocks change, where adding 24 hours wouldn't. Dates are in the time's own location, so a holiday starts at midnight there, and Friday evening in London is already Saturday in Tokyo.
+	*/
+	ny, _ := time.LoadLocation("America/New_York")
+	friday = time.Date(2021, 3, 12, 9, 0, 0, 0, ny)
+	fmt.Println(c.addWorkdays(friday, 1).Format(layout+" MST"), friday.Add(3*day).Format(layout+" MST")) // Prints Mon 2021-03-15 09:00 EDT Mon 2021-03-15 10:00 EDT
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	fmt.Println(c.isWorkday(date("2021-12-24 20:00")), c.isWorkday(date("2021-12-24 20:00").In(tokyo))) // Prints true false
+
+	_, err = newCalendar("2021-12-25", "25/12/2021")
+	fmt.Println(err) // Prints holiday "25/12/2021": parsing time "25/12/2021" as "2006-01-02": cannot parse "25/12/2021" as "2006"
+}
@@ -0,0 +1,205 @@
+package times
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Durations
+
+const day = 24 * time.Hour
+
+// humanise writes d the way a person would say it, to no more precision than
+// they would care about: whole days once it is a day or more, then hours and
+// minutes, minutes and seconds, or seconds, rounded to the nearest, like
+// "3 days", "2h 5m" or "45s". The sign is ignored; relative says which way.
+func humanise(d time.Duration) string {
+	if d < 0 {
+		d = -d
+	}
+	switch {
+	case d >= time.Hour:
+		d = d.Round(time.Minute)
+	default:
+		d = d.Round(time.Second)
+	}
+	// Rounding can carry into the next unit up, like 59m59.6s to 1h, so the
+	// unit is picked from the rounded value.
+	switch {
+	case d >= day:
+		n := int64(d.Round(day) / day)
+		if n == 1 {
+			return "1 day"
+		}
+		return fmt.Sprintf("%d days", n)
+	case d >= time.Hour:
+		return units(int64(d/time.Hour), "h", int64(d%time.Hour/time.Minute), "m")
+	case d >= time.Minute:
+		return units(int64(d/time.Minute), "m", int64(d%time.Minute/time.Second), "s")
+	}
+	return fmt.Sprintf("%ds", int64(d/time.Second))
+}
+
+// units writes a number of a unit and of the next unit down, leaving the
+// second out when it is zero.
+func units(n int64, unit string, m int64, next string) string {
+	if m == 0 {
+		return fmt.Sprintf("%d%s", n, unit)
+	}
+	return fmt.Sprintf("%d%s %d%s", n, unit, m, next)
+}
+
+// relative says when t is from now, like "3 days ago" or "in 2h 5m".
+func relative(t, now time.Time) string {
+	d := t.Sub(now)
+	switch {
+	case d > -time.Second/2 && d < time.Second/2:
+		return "now"
+	case d < 0:
+		return humanise(d) + " ago"
+	}
+	return "in " + humanise(d)
+}
+
+// parseDuration parses a duration as time.ParseDuration does, and also
+// understands d for days and w for weeks, like "1w2d" or "1.5d". A day is
+// taken to be 24 hours, which a day isn't when the clocks change, so a
+// number of days to add to a date is better given to AddDate.
+func parseDuration(s string) (time.Duration, error) {
+	orig := s
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	if s == "0" {
+		return 0, nil
+	}
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration %q", orig)
+	}
+	var total time.Duration
+	for s != "" {
+		i := 0
+		for i < len(s) && (s[i] == '.' || '0' <= s[i] && s[i] <= '9') {
+			i++
+		}
+		j := i
+		for j < len(s) && s[j] != '.' && (s[j] < '0' || s[j] > '9') {
+			j++
+		}
+		num, unit := s[:i], s[i:j]
+		s = s[j:]
+
+		switch {
+		case num == "":
+			return 0, fmt.Errorf("invalid duration %q", orig)
+		case unit == "":
+			return 0, fmt.Errorf("missing unit in duration %q", orig)
+		}
+		var d time.Duration
+		switch unit {
+		case "d", "w":
+			f, err := strconv.ParseFloat(num, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", orig)
+			}
+			f *= float64(day)
+			if unit == "w" {
+				f *= 7
+			}
+			if f >= 1<<63 {
+				return 0, fmt.Errorf("duration %q is too long", orig)
+			}
+			d = time.Duration(f)
+		case "ns", "us", "µs", "μs", "ms", "s", "m", "h":
+			var err error
+			if d, err = time.ParseDuration(num + unit); err != nil {
+				return 0, fmt.Errorf("invalid duration %q", orig)
+			}
+		default:
+			return 0, fmt.Errorf("unknown unit %q in duration %q", unit, orig)
+		}
+		if total > 1<<63-1-d {
+			return 0, fmt.Errorf("duration %q is too long", orig)
+		}
+		total += d
+	}
+	if neg {
+		total = -total
+	}
+	return total, nil
+}
+
+// Business Days
+
+// A calendar knows which days are working days: the ones that aren't at the
+// weekend or holidays.
+type calendar struct {
+	holidays map[string]bool // Dates in the "2006-01-02" layout
+}
+
+// newCalendar returns a calendar with the holidays given, as dates like
+// "2021-12-25". Which days are holidays depends on the country, and often
+// the region or the company, so there is no default list.
+func newCalendar(holidays ...string) (*calendar, error) {
+	c := &calendar{holidays: make(map[string]bool)}
+	for _, h := range holidays {
+		t, err := time.Parse("2006-01-02", h)
+		if err != nil {
+			return nil, fmt.Errorf("holiday %q: %v", h, err)
+		}
+		c.holidays[t.Format("2006-01-02")] = true
+	}
+	return c, nil
+}
+
+// isWorkday reports whether t's date, in t's location, is a working day.
+func (c *calendar) isWorkday(t time.Time) bool {
+	switch t.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return !c.holidays[t.Format("2006-01-02")]
+}
+
+// addWorkdays returns the time n working days after t, or before it if n is
+// negative, at the same time of day. Days that aren't working days don't
+// count, so a working day after Friday is Monday, and one after Saturday is
+// Monday too.
+func (c *calendar) addWorkdays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step) // Rather than Add(24 * time.Hour), which isn't a day when the clocks change
+		if c.isWorkday(t) {
+			n--
+		}
+	}
+	return t
+}
+
+// workdaysBetween returns the number of working days after from, up to and
+// including to. If to is earlier, it counts back, and returns minus the
+// number from to up to but not including from, so either way it is the n
+// that addWorkdays would take to get from from to to, when to is a working
+// day. Only the dates count, not the times of day. It steps through the days
+// one at a time, which is fine for the spans people plan in.
+func (c *calendar) workdaysBetween(from, to time.Time) int {
+	if to.Before(from) {
+		return -c.workdaysBetween(to.AddDate(0, 0, -1), from.AddDate(0, 0, -1))
+	}
+	from = time.Date(from.Year(), from.Month(), from.Day(), 12, 0, 0, 0, from.Location()) // Midday is never skipped by a clock change
+	end := to.In(from.Location()).Format("2006-01-02")
+	n := 0
+	for from.Format("2006-01-02") < end {
+		from = from.AddDate(0, 0, 1)
+		if c.isWorkday(from) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,244 @@
+package times
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		err  string
+	}{
+		{"1w2d", 9 * day, ""},
+		{"1d12h", 36 * time.Hour, ""},
+		{"1.5d", 36 * time.Hour, ""},
+		{"2w", 14 * day, ""},
+		{"-1w", -7 * day, ""},
+		{"+3d", 3 * day, ""},
+		{"-1d12h", -36 * time.Hour, ""}, // The sign is for the whole duration
+		{"1h30m", 90 * time.Minute, ""},
+		{"1d1h1m1s1ms1us1ns", day + time.Hour + time.Minute + time.Second + time.Millisecond + time.Microsecond + time.Nanosecond, ""},
+		{"1µs1μs", 2 * time.Microsecond, ""}, // The micro sign and the Greek letter mu
+		{"0.5w", 84 * time.Hour, ""},
+		{".5d", 12 * time.Hour, ""},
+		{"2d3d", 5 * day, ""},
+		{"0", 0, ""},
+		{"-0", 0, ""},
+		{"0d", 0, ""},
+		{"", 0, `invalid duration ""`},
+		{"-", 0, `invalid duration "-"`},
+		{"3", 0, `missing unit in duration "3"`},
+		{"1d2", 0, `missing unit in duration "1d2"`},
+		{"2y", 0, `unknown unit "y" in duration "2y"`},
+		{"1D", 0, `unknown unit "D" in duration "1D"`},
+		{"1d 2h", 0, `unknown unit "d " in duration "1d 2h"`},
+		{"d", 0, `invalid duration "d"`},
+		{"1..5d", 0, `invalid duration "1..5d"`},
+		{"1.2.3h", 0, `invalid duration "1.2.3h"`},
+		{"--1d", 0, `invalid duration "--1d"`},
+		{"15000w", 15000 * 7 * day, ""},
+		{"20000w", 0, `duration "20000w" is too long`},
+		{"15000w15000w", 0, `duration "15000w15000w" is too long`},
+		{"106751d23h47m16s", 2562047*time.Hour + 47*time.Minute + 16*time.Second, ""}, // Nearly the longest a Duration can be
+		{"106751d23h47m17s", 0, `duration "106751d23h47m17s" is too long`},
+	}
+	for _, test := range tests {
+		got, err := parseDuration(test.in)
+		gotErr := ""
+		if err != nil {
+			gotErr = err.Error()
+		}
+		if got != test.want || gotErr != test.err {
+			t.Errorf("parseDuration(%q) = %v %q, want %v %q", test.in, got, gotErr, test.want, test.err)
+		}
+	}
+}
+
+func TestHumanise(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{400 * time.Millisecond, "0s"},
+		{1500 * time.Millisecond, "2s"},
+		{45 * time.Second, "45s"},
+		{59*time.Second + 600*time.Millisecond, "1m"},
+		{time.Minute, "1m"},
+		{5*time.Minute + 30*time.Second, "5m 30s"},
+		{59*time.Minute + 59*time.Second + 600*time.Millisecond, "1h"},
+		{time.Hour + 20*time.Second, "1h"},
+		{2*time.Hour + 5*time.Minute, "2h 5m"},
+		{2*time.Hour + 5*time.Minute + 40*time.Second, "2h 6m"},
+		{23*time.Hour + 59*time.Minute, "23h 59m"},
+		{23*time.Hour + 59*time.Minute + 40*time.Second, "1 day"},
+		{35 * time.Hour, "1 day"},
+		{36 * time.Hour, "2 days"},
+		{74 * time.Hour, "3 days"},
+		{-74 * time.Hour, "3 days"},
+		{400 * day, "400 days"},
+	}
+	for _, test := range tests {
+		if got := humanise(test.d); got != test.want {
+			t.Errorf("humanise(%v) = %q, want %q", test.d, got, test.want)
+		}
+	}
+
+	now := time.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	relatives := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "now"},
+		{499 * time.Millisecond, "now"},
+		{-499 * time.Millisecond, "now"},
+		{500 * time.Millisecond, "in 1s"},
+		{-500 * time.Millisecond, "1s ago"},
+		{2*time.Hour + 5*time.Minute, "in 2h 5m"},
+		{-90 * time.Second, "1m 30s ago"},
+		{-74 * time.Hour, "3 days ago"},
+		{24 * time.Hour, "in 1 day"},
+	}
+	for _, test := range relatives {
+		if got := relative(now.Add(test.d), now); got != test.want {
+			t.Errorf("relative(now + %v) = %q, want %q", test.d, got, test.want)
+		}
+	}
+}
+
+// The holidays are England's for the end of 2021, when Christmas Day and
+// Boxing Day fell at the weekend and the holidays moved to the Monday and
+// Tuesday after.
+func TestBusinessDays(t *testing.T) {
+	c, err := newCalendar("2021-12-27", "2021-12-28", "2022-01-03")
+	if err != nil {
+		t.Fatal(err)
+	}
+	date := func(s string) time.Time {
+		t.Helper()
+		d, err := time.Parse("2006-01-02 15:04", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+
+	workdays := []struct {
+		date string
+		want bool
+	}{
+		{"2021-12-20 09:00", true},  // Monday
+		{"2021-12-24 09:00", true},  // Friday, Christmas Eve isn't a holiday
+		{"2021-12-25 09:00", false}, // Saturday
+		{"2021-12-26 09:00", false}, // Sunday
+		{"2021-12-27 09:00", false}, // Monday, a holiday
+		{"2021-12-29 09:00", true},  // Wednesday
+		{"2022-01-03 00:00", false}, // Monday, a holiday from its first minute
+		{"2022-01-03 23:59", false}, // to its last
+		{"2022-01-04 00:00", true},
+	}
+	for _, test := range workdays {
+		if got := c.isWorkday(date(test.date)); got != test.want {
+			t.Errorf("isWorkday(%s) = %v, want %v", test.date, got, test.want)
+		}
+	}
+
+	adds := []struct {
+		from string
+		n    int
+		want string
+	}{
+		{"2021-12-20 09:00", 0, "2021-12-20 09:00"},
+		{"2021-12-25 09:00", 0, "2021-12-25 09:00"}, // Nothing to add, even from a Saturday
+		{"2021-12-20 09:00", 1, "2021-12-21 09:00"},
+		{"2021-12-23 17:30", 1, "2021-12-24 17:30"},  // Thursday to Friday
+		{"2021-12-24 17:30", 1, "2021-12-29 17:30"},  // Over the weekend and both holidays
+		{"2021-12-25 12:00", 1, "2021-12-29 12:00"},  // From a Saturday
+		{"2021-12-17 09:00", 10, "2022-01-05 09:00"}, // Two weeks, less three holidays
+		{"2021-12-29 09:00", -1, "2021-12-24 09:00"},
+		{"2021-12-27 09:00", -1, "2021-12-24 09:00"}, // Back from a holiday
+		{"2021-12-26 09:00", -1, "2021-12-24 09:00"}, // and from a Sunday
+		{"2022-01-05 09:00", -10, "2021-12-17 09:00"},
+		{"2022-01-07 09:00", 260, "2023-01-06 09:00"}, // Weekends only, after 2022
+		{"2024-02-28 09:00", 1, "2024-02-29 09:00"},
+		{"2023-02-28 09:00", 1, "2023-03-01 09:00"},
+	}
+	for _, test := range adds {
+		got := c.addWorkdays(date(test.from), test.n).Format("2006-01-02 15:04")
+		if got != test.want {
+			t.Errorf("addWorkdays(%s, %d) = %s, want %s", test.from, test.n, got, test.want)
+		}
+	}
+
+	betweens := []struct {
+		from, to string
+		want     int
+	}{
+		{"2021-12-20 09:00", "2021-12-20 17:00", 0},
+		{"2021-12-20 17:00", "2021-12-21 09:00", 1}, // Only the dates count
+		{"2021-12-20 09:00", "2021-12-24 09:00", 4},
+		{"2021-12-24 09:00", "2021-12-29 09:00", 1},
+		{"2021-12-24 09:00", "2021-12-28 09:00", 0}, // All weekend and holidays
+		{"2021-12-17 09:00", "2022-01-05 09:00", 10},
+		{"2022-01-05 09:00", "2021-12-17 09:00", -10},
+		{"2021-12-29 09:00", "2021-12-24 09:00", -1},
+		{"2022-01-07 09:00", "2023-01-06 09:00", 260},
+	}
+	for _, test := range betweens {
+		if got := c.workdaysBetween(date(test.from), date(test.to)); got != test.want {
+			t.Errorf("workdaysBetween(%s, %s) = %d, want %d", test.from, test.to, got, test.want)
+		}
+	}
+
+	// Adding and counting agree, from any day to a working day.
+	start := date("2021-12-18 09:00")
+	for n := -15; n <= 15; n++ {
+		if got := c.workdaysBetween(start, c.addWorkdays(start, n)); got != n {
+			t.Errorf("workdaysBetween(%s, addWorkdays(%[1]s, %d)) = %d", start.Format("2006-01-02"), n, got)
+		}
+	}
+}
+
+// Days are dates in the time's own location, and adding them keeps the time
+// of day when the clocks change.
+func TestBusinessDaysInLocations(t *testing.T) {
+	c, err := newCalendar("2021-12-27")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	friday := time.Date(2021, time.March, 12, 9, 0, 0, 0, ny) // The clocks went forward that Sunday
+	if got, want := c.addWorkdays(friday, 1), time.Date(2021, time.March, 15, 9, 0, 0, 0, ny); !got.Equal(want) {
+		t.Errorf("addWorkdays(%v, 1) = %v, want %v", friday, got, want)
+	}
+	if got := c.workdaysBetween(friday, time.Date(2021, time.March, 15, 8, 0, 0, 0, ny)); got != 1 {
+		t.Errorf("workdaysBetween over the change = %d, want 1", got)
+	}
+
+	evening := time.Date(2021, time.December, 24, 20, 0, 0, 0, time.UTC)
+	if !c.isWorkday(evening) || c.isWorkday(evening.In(tokyo)) {
+		t.Errorf("Friday evening in UTC should be a working day, and Saturday morning in Tokyo not")
+	}
+	sunday := time.Date(2021, time.December, 26, 20, 0, 0, 0, time.UTC)
+	if c.isWorkday(sunday) || c.isWorkday(sunday.In(tokyo)) {
+		t.Errorf("Sunday evening in UTC is Monday the 27th in Tokyo, a holiday, so neither should be a working day")
+	}
+}
+
+func TestNewCalendarErrors(t *testing.T) {
+	for _, holiday := range []string{"25/12/2021", "2021-02-30", "2021-12-25 00:00", ""} {
+		if _, err := newCalendar("2021-12-27", holiday); err == nil {
+			t.Errorf("newCalendar(%q) succeeded, want an error", holiday)
+		}
+	}
+}
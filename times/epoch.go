@@ -0,0 +1,28 @@
+package times
+
+import "time"
+
+// Epoch
+
+// FromUnix converts a Unix timestamp to a time, working out whether it is in
+// seconds, milliseconds, microseconds or nanoseconds from how big it is, and
+// returning that unit too. Timestamps in seconds are under 10^11 until the
+// year 5138, and ones for the same time in milliseconds are a thousand times
+// bigger, so for any time since 1973 and before 5138 the units can't be
+// confused. It is exported for the epoch command.
+func FromUnix(n int64) (time.Time, time.Duration) {
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs < 1e11:
+		return time.Unix(n, 0), time.Second
+	case abs < 1e14:
+		return time.UnixMilli(n), time.Millisecond
+	case abs < 1e17:
+		return time.UnixMicro(n), time.Microsecond
+	default:
+		return time.Unix(0, n), time.Nanosecond
+	}
+}
@@ -0,0 +1,217 @@
+// Code generated by "go run . examples -w" from the Prints comments. DO NOT EDIT.
+
+package times_test
+
+import (
+	"github.com/omussell/go-by-example/clock"
+	"github.com/omussell/go-by-example/times"
+)
+
+func ExampleTime() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.Time()
+	// Output:
+	// 2009-11-10 23:00:00 +0000 UTC
+	// 2009-11-17 20:34:58.651387237 +0000 UTC
+	// 2009 November 17
+	// 20 34 58 651387237
+	// UTC Tuesday 321
+	// 2009 47
+	// 2009 November 17 20 34 58
+	// 2023-03-01 00:00:00 +0000 UTC
+	// 2024-01-02 01:00:00 +0000 UTC
+	// 29
+	// 0001-01-01 00:00:00 +0000 UTC true
+}
+
+func ExampleComparingTimes() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.ComparingTimes()
+	// Output:
+	// false true false
+	// 2009-11-18 05:34:58.651387237 +0900 JST
+	// false true
+	// true
+	// 2009-11-17 20:34:58.651387237 +0000 UTC
+}
+
+func ExampleTimeArithmetic() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.TimeArithmetic()
+	// Output:
+	// 165h34m58.651387237s
+	// 165.58295871867693 9934.977523120617
+	// 166h0m0s 165h34m0s
+	// 2009-11-24 18:09:57.302774474 +0000 UTC
+	// 2009-11-10 23:00:00 +0000 UTC
+	// 2009-11-17 22:04:58.651387237 +0000 UTC
+	// -165h34m58.651387237s
+	// 2009-12-17 20:34:58.651387237 +0000 UTC
+	// 2023-03-03 00:00:00 +0000 UTC
+	// 2009-11-17 20:00:00 +0000 UTC
+	// 2009-11-17 21:00:00 +0000 UTC
+	// 2009-11-17 20:34:59 +0000 UTC
+	// 2009-11-17 00:00:00 +0000 UTC
+}
+
+func ExampleMonotonicClock() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.MonotonicClock()
+	// Output:
+	// true
+	// true
+	// false
+	// true
+	// false
+	// false true
+	// false true
+}
+
+func ExampleTimeZones() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.TimeZones()
+	// Output:
+	// unknown time zone Mars/Olympus_Mons
+	// 2009-11-10 18:00:00 -0500 EST
+	// 2009-11-11 08:00:00 +0900 JST
+	// true
+	// 2021-01-15 12:00:00 -0500 EST 2021-07-15 12:00:00 -0400 EDT
+	// EDT -4
+	// 2021-03-14 12:00:00 -0400 EDT
+	// 2021-03-14 13:00:00 -0400 EDT
+	// 23h0m0s
+	// 2021-03-14 01:30:00 -0500 EST
+	// 2021-11-07 01:30:00 -0400 EDT
+}
+
+func ExampleTimeFormatting() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.TimeFormatting()
+	// Output:
+	// 2009-11-10T23:04:05Z
+	// 2009-11-10T23:04:05.123456789Z
+	// 11:04PM
+	// Tue Nov 10 23:04:05 2009
+	// 2009-11-10T23:04:05.123456+00:00
+	// Tuesday, 10 November 2009 at 11:04pm
+	// 09/11/10 23h04
+	// 23:04:05.123000
+	// 23:04:05.123
+	// 101011-11-10
+	// 2009-11-10 day 314 of the year
+}
+
+func ExampleTimeParsing() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.TimeParsing()
+	// Output:
+	// 2012-11-01 22:08:41 +0000 UTC <nil>
+	// 0000-01-01 20:41:00 +0000 UTC
+	// 2009-11-10 18:00:00 +0000 UTC
+	// 2009-11-10 18:00:00 -0500 EST 2009-11-10 23:00:00 +0000 UTC
+	// 2009-11-10 18:00:00 -0500 EST 2009-11-10 23:00:00 +0000 UTC
+	// 2009-11-10 15:00:00 +0000 PST 2009-11-10 15:00:00 +0000 UTC
+	// parsing time "2009-11-10 23:00:00Z" as "2006-01-02T15:04:05Z07:00": cannot parse " 23:00:00Z" as "T"
+	// "T" " 23:00:00Z"
+	// parsing time "2009-02-30": day out of range
+	// "" "" ": day out of range"
+}
+
+func ExampleDetectingLayouts() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.DetectingLayouts()
+	// Output:
+	// 2009-11-10 18:00:00 -0500 EST syslog <nil>
+	// "10 Nov 2009" is not a timestamp in any known layout
+	// "Feb 29 12:00:00" is not a date in 2009
+}
+
+func ExampleEpoch() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.Epoch()
+	// Output:
+	// 2009-11-10 23:00:00 +0000 UTC
+	// 1257894000
+	// 1257894000000
+	// 1257894000000000
+	// 1257894000000000000
+	// 2009-11-10 23:00:00 +0000 UTC
+	// 2009-11-10 23:00:00 +0000 UTC
+	// 2009-11-10 23:00:00 +0000 UTC
+	// 2009-11-10 23:00:00.123456789 +0000 UTC
+	// 1969-12-31 23:59:59 +0000 UTC
+	// 10413792000 1715
+	// 1257894000           1s   2009-11-10T23:00:00Z
+	// 1257894000123        1ms  2009-11-10T23:00:00.123Z
+	// 1257894000123456     1µs  2009-11-10T23:00:00.123456Z
+	// 1257894000123456789  1ns  2009-11-10T23:00:00.123456789Z
+	// 0                    1s   1970-01-01T00:00:00Z
+	// -86400               1s   1969-12-31T00:00:00Z
+	// 100000000000         1ms  1973-03-03T09:46:40Z
+}
+
+func ExampleCronSchedules() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.CronSchedules()
+	// Output:
+	// Wed 2009-11-11 08:30 EST
+	// Wed 2009-11-11 12:30 EST
+	// Thu 2009-11-12 08:30 EST
+	// Thu 2009-11-12 12:30 EST
+	// Mon 2021-03-15 02:30 EDT
+	// Sun 2021-11-07 01:30 EDT Mon 2021-11-08 01:30 EST
+	// cron: "0 17-9 * * *": hour field: range "17-9" goes backwards
+}
+
+func ExampleSchedulingJobs() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.SchedulingJobs()
+	// Output:
+	// starting at 23:00:00
+	// ping at 23:01
+	// report started at 23:02
+	// report skipped at 23:04
+	// report finished at 23:04:30
+	// ping at 23:05
+	// report started at 23:06
+	// report cancelled at 23:07:30
+	// stopped at 23:07:30 context canceled
+}
+
+func ExampleDurations() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.Durations()
+	// Output:
+	// 1h15m30.5s <nil>
+	// 300ms -1h30m0s 1h30m0s 1µs
+	// time: unknown unit "d" in duration "2d"
+	// 1.2584722222222222 75.50833333333334 4530.5
+	// 75 75ns 30.5s
+	// 3s 3s
+	// 50h3m7.123456789s 50h3m7s 50h3m0s
+	// 50:03:07
+	// 228h0m0s
+	// unknown unit "y" in duration "2y"
+}
+
+func ExampleHumanisingDurations() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.HumanisingDurations()
+	// Output:
+	// 74h3m7.123456789s 3 days
+	// 2h 6m
+	// 3 days ago, in 2h 5m, 1m 30s ago, now
+}
+
+func ExampleBusinessDays() {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	times.BusinessDays()
+	// Output:
+	// true false
+	// Wed 2021-12-29 17:30
+	// Fri 2021-12-17 17:30
+	// 10
+	// Mon 2021-03-15 09:00 EDT Mon 2021-03-15 10:00 EDT
+	// true false
+	// holiday "25/12/2021": parsing time "25/12/2021" as "2006-01-02": cannot parse "25/12/2021" as "2006"
+}
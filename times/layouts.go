@@ -0,0 +1,68 @@
+package times
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Detecting Layouts
+
+// A namedLayout is a layout that ParseAny tries, with a name to report.
+type namedLayout struct {
+	name   string
+	layout string
+}
+
+// knownLayouts are the timestamp formats seen in logs from various systems,
+// most specific first, as the first that parses wins. Numeric dates are
+// taken to be day/month in the common log format and month/day otherwise,
+// which is a guess: 03/04/2021 is ambiguous, and only knowing where it came
+// from can say which it is.
+var knownLayouts = []namedLayout{
+	{"RFC 3339", time.RFC3339Nano}, // Parses RFC3339 too, as fractional seconds are optional when parsing
+	{"ISO 8601 without a zone", "2006-01-02T15:04:05.999999999"},
+	{"ISO 8601 basic", "20060102T150405Z0700"},
+	{"date and time", "2006-01-02 15:04:05.999999999Z07:00"},
+	{"date and time without a zone", "2006-01-02 15:04:05.999999999"}, // As SQL and Python's logging write them
+	{"RFC 1123", time.RFC1123Z},
+	{"RFC 1123 with a zone name", time.RFC1123},
+	{"RFC 850", time.RFC850},
+	{"Ruby date", time.RubyDate},
+	{"Unix date", time.UnixDate}, // After Ruby's, as a zone name like MST also matches -0700
+	{"ANSI C", time.ANSIC},
+	{"common log format", "02/Jan/2006:15:04:05 -0700"},
+	{"syslog", time.Stamp},
+	{"US date and time", "01/02/2006 15:04:05"},
+	{"US date and kitchen time", "1/2/2006 3:04 PM"},
+	{"date", "2006-01-02"},
+	{"US date", "01/02/2006"},
+}
+
+// ParseAny parses a timestamp in any of the knownLayouts, and says which one
+// it was. Times without a zone are taken to be in loc, and ones without a
+// year, like syslog's, in the year of now, which is an error if that year
+// doesn't have the date, like Feb 29 in 2009. It is exported for the epoch
+// command.
+func ParseAny(s string, loc *time.Location, now time.Time) (time.Time, string, error) {
+	s = strings.TrimSpace(s)
+	for _, l := range knownLayouts {
+		t, err := time.ParseInLocation(l.layout, s, loc)
+		if err != nil {
+			continue
+		}
+		if t.Year() == 0 {
+			// Parse puts a time without a year in year 0, which was a leap
+			// year, so the date has to be built again rather than moved on,
+			// or Feb 29 would quietly become Mar 1.
+			year := now.In(loc).Year()
+			d := time.Date(year, t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+			if d.Month() != t.Month() || d.Day() != t.Day() {
+				return time.Time{}, "", fmt.Errorf("%q is not a date in %d", s, year)
+			}
+			t = d
+		}
+		return t, l.name, nil
+	}
+	return time.Time{}, "", fmt.Errorf("%q is not a timestamp in any known layout", s)
+}
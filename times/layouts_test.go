@@ -0,0 +1,92 @@
+package times
+
+import (
+	"testing"
+	"time"
+
+	"github.com/omussell/go-by-example/clock"
+)
+
+// The test cases are timestamps from logs, including some messy ones, read
+// in New York on the Go playground's date.
+func TestParseAny(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := clock.Playground
+	tests := []struct {
+		in     string
+		layout string
+		want   string // RFC3339Nano
+	}{
+		{"2009-11-10T23:00:00Z", "RFC 3339", "2009-11-10T23:00:00Z"},
+		{"2009-11-10T23:00:00.123456+01:00", "RFC 3339", "2009-11-10T23:00:00.123456+01:00"},
+		{"  2009-11-10T23:00:00Z\n", "RFC 3339", "2009-11-10T23:00:00Z"},
+		{"2009-11-10T18:00:00", "ISO 8601 without a zone", "2009-11-10T18:00:00-05:00"},
+		{"20091110T230000Z", "ISO 8601 basic", "2009-11-10T23:00:00Z"},
+		{"2009-11-10 23:00:00+00:00", "date and time", "2009-11-10T23:00:00Z"},
+		{"2009-11-10 18:00:00.5", "date and time without a zone", "2009-11-10T18:00:00.5-05:00"},
+		{"2009-11-10 18:00:00,250", "date and time without a zone", "2009-11-10T18:00:00.25-05:00"},
+		{"Tue, 10 Nov 2009 23:00:00 +0000", "RFC 1123", "2009-11-10T23:00:00Z"},
+		{"Tue, 10 Nov 2009 18:00:00 EST", "RFC 1123 with a zone name", "2009-11-10T18:00:00-05:00"},
+		{"Tuesday, 10-Nov-09 18:00:00 EST", "RFC 850", "2009-11-10T18:00:00-05:00"},
+		{"Tue Nov 10 18:00:00 EST 2009", "Unix date", "2009-11-10T18:00:00-05:00"},
+		{"Tue Nov 10 23:00:00 +0000 2009", "Ruby date", "2009-11-10T23:00:00Z"},
+		{"Tue Nov 10 18:00:00 2009", "ANSI C", "2009-11-10T18:00:00-05:00"},
+		{"10/Nov/2009:23:00:00 +0000", "common log format", "2009-11-10T23:00:00Z"},
+		{"Nov 10 18:00:00", "syslog", "2009-11-10T18:00:00-05:00"},
+		{"Nov  3 09:15:00", "syslog", "2009-11-03T09:15:00-05:00"},
+		{"11/10/2009 18:00:00", "US date and time", "2009-11-10T18:00:00-05:00"},
+		{"11/10/2009 6:00 PM", "US date and kitchen time", "2009-11-10T18:00:00-05:00"},
+		{"2009-11-10", "date", "2009-11-10T00:00:00-05:00"},
+		{"11/10/2009", "US date", "2009-11-10T00:00:00-05:00"},
+		{"10 Nov 2009", "", ""},
+		{"2009-11-31", "", ""},
+		{"yesterday", "", ""},
+		{"Feb 29 12:00:00", "", ""}, // 2009 wasn't a leap year
+	}
+	for _, test := range tests {
+		got, layout, err := ParseAny(test.in, ny, now)
+		want := ""
+		if err == nil {
+			want = got.Format(time.RFC3339Nano)
+		}
+		if layout != test.layout || want != test.want {
+			t.Errorf("ParseAny(%q) = %s %q, want %s %q", test.in, want, layout, test.want, test.layout)
+		}
+	}
+}
+
+// Syslog timestamps don't have a year, so they are read in the current
+// year, which doesn't always have a 29th of February.
+func TestParseAnyWithoutYear(t *testing.T) {
+	tests := []struct {
+		in   string
+		now  string
+		want string
+		err  string
+	}{
+		{"Feb 29 12:00:00", "2024-06-01T00:00:00Z", "2024-02-29T12:00:00Z", ""},
+		{"Feb 29 12:00:00", "2023-06-01T00:00:00Z", "", `"Feb 29 12:00:00" is not a date in 2023`},
+		{"Feb 28 12:00:00", "2023-06-01T00:00:00Z", "2023-02-28T12:00:00Z", ""},
+		{"Dec 31 23:59:59", "2024-01-01T00:00:00Z", "2024-12-31T23:59:59Z", ""},
+		{"Jan  1 00:00:00", "2023-12-31T23:00:00-05:00", "2024-01-01T00:00:00Z", ""}, // The year in UTC, where the times are read
+	}
+	for _, test := range tests {
+		now, err := time.Parse(time.RFC3339, test.now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _, err := ParseAny(test.in, time.UTC, now)
+		gotTime, gotErr := "", ""
+		if err != nil {
+			gotErr = err.Error()
+		} else {
+			gotTime = got.Format(time.RFC3339)
+		}
+		if gotTime != test.want || gotErr != test.err {
+			t.Errorf("ParseAny(%q) in %s = %s %q, want %s %q", test.in, test.now, gotTime, gotErr, test.want, test.err)
+		}
+	}
+}
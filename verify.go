@@ -0,0 +1,300 @@
+// Verifying the Prints comments
+//
+// Examples note what they print in comments like
+//
+//	fmt.Println(fact(7)) // Prints 5040
+//
+// or, for several lines,
+//
+//	/*
+//	   Prints:
+//	   1
+//	   2
+//	*/
+//
+// Anything after the first ". " of a one line comment is explanation, and
+// " \n " separates lines. "Prints, in any order:" is for output such as map
+// iteration that changes between runs, and "Prints one of:" for a single line
+// that can be any of the alternatives given. Pointer addresses like 0xc000012345
+// match any address, while shorter hex numbers are compared as they are.
+//
+// A section passes when its output is exactly the lines described by all of
+// its Prints comments, in order. Sections are checked as though it were
+// clock.Playground, with sleeps passing instantly, so that what they print
+// doesn't depend on when they are run.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/omussell/go-by-example/clock"
+	"github.com/omussell/go-by-example/section"
+)
+
+// An expectation is the output described by one Prints comment.
+type expectation struct {
+	pos      token.Position
+	lines    []string
+	anyOrder bool // The lines can come out in any order
+	oneOf    bool // A single line, which is one of lines
+}
+
+var printsComment = regexp.MustCompile(`^[Pp]rints(,? in any order| one of)?:?(?:\s+|$)`)
+
+// parsePrints returns the expectation in a comment, if there is one.
+func parsePrints(text string) (lines []string, qualifier string, ok bool) {
+	block := strings.HasPrefix(text, "/*")
+	if block {
+		text = strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	} else {
+		text = strings.TrimPrefix(text, "//")
+	}
+
+	if block {
+		all := strings.Split(text, "\n")
+		for len(all) > 0 && strings.TrimSpace(all[0]) == "" {
+			all = all[1:]
+		}
+		if len(all) == 0 {
+			return nil, "", false
+		}
+		first := strings.TrimSpace(all[0])
+		m := printsComment.FindStringSubmatch(first)
+		if m == nil {
+			return nil, "", false
+		}
+		if rest := first[len(m[0]):]; rest != "" {
+			lines = append(lines, rest)
+		}
+		// Only the indentation shared by every line is dropped, as output
+		// lines can start with spaces too.
+		lines = append(lines, dedent(all[1:])...)
+		return lines, m[1], true
+	}
+
+	text = strings.TrimSpace(text)
+	m := printsComment.FindStringSubmatch(text)
+	if m == nil {
+		return nil, "", false
+	}
+	text = text[len(m[0]):]
+	if i := strings.Index(text, ". "); i >= 0 {
+		text = text[:i]
+	}
+	if strings.HasSuffix(text, ".") && !strings.HasSuffix(text, "..") {
+		text = strings.TrimSuffix(text, ".")
+	}
+	for _, l := range strings.Split(text, `\n`) {
+		lines = append(lines, strings.TrimSpace(l))
+	}
+	return lines, m[1], true
+}
+
+// expectations collects the Prints comments of a section in source order.
+func (ss *sectionSource) expectations() []expectation {
+	var es []expectation
+	for _, c := range ss.comments() {
+		lines, q, ok := parsePrints(c.Text)
+		if !ok {
+			continue
+		}
+		es = append(es, expectation{
+			pos:      ss.fset.Position(c.Pos()),
+			lines:    lines,
+			anyOrder: strings.Contains(q, "any order"),
+			oneOf:    strings.Contains(q, "one of"),
+		})
+	}
+	return es
+}
+
+// pointer matches the heap addresses Go prints for pointers, which have at
+// least nine hex digits on 64-bit platforms, rather than any hex number, so
+// that output like 0x10 or 0x1p-02 is still compared. Where the heap starts
+// varies, so the digits themselves can't be relied on.
+var pointer = regexp.MustCompile(`\b0x[0-9a-f]{9,}\b`)
+
+func normalise(line string) string {
+	return strings.TrimSpace(pointer.ReplaceAllString(line, "0x…"))
+}
+
+// A wantLine is a line of expected output and the comment it came from.
+type wantLine struct {
+	text string
+	pos  token.Position
+}
+
+// match lines up the expectations against the actual output. Lines from
+// "any order" and "one of" expectations are taken in the order they were
+// actually printed, when they can be matched, so only real differences show
+// up in the diff.
+func match(es []expectation, got []string) []wantLine {
+	var want []wantLine
+	i := 0
+	for _, e := range es {
+		n := len(e.lines)
+		if e.oneOf {
+			n = 1
+		}
+		var next []string
+		if i+n <= len(got) {
+			next = got[i : i+n]
+		}
+		lines := e.lines
+		switch {
+		case e.oneOf && next != nil:
+			lines = e.lines[:1]
+			for _, l := range e.lines {
+				if normalise(l) == normalise(next[0]) {
+					lines = next
+				}
+			}
+		case e.anyOrder && next != nil && sameLines(e.lines, next):
+			lines = next
+		}
+		for _, l := range lines {
+			want = append(want, wantLine{l, e.pos})
+		}
+		i += n
+	}
+	return want
+}
+
+func sameLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	x := make([]string, len(a))
+	y := make([]string, len(b))
+	for i := range a {
+		x[i], y[i] = normalise(a[i]), normalise(b[i])
+	}
+	sort.Strings(x)
+	sort.Strings(y)
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// diff compares the expected and actual output line by line. It returns nil
+// when they match, otherwise the lines of a diff, with missing lines marked
+// "-" along with the comment they came from, and unexpected ones marked "+".
+func diff(want []wantLine, got []string) []string {
+	n, m := len(want), len(got)
+	// lcs[i][j] is the length of the longest common subsequence of want[i:]
+	// and got[j:].
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if normalise(want[i].text) == normalise(got[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	if lcs[0][0] == n && n == m {
+		return nil
+	}
+
+	var d []string
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && normalise(want[i].text) == normalise(got[j]):
+			d = append(d, "  "+got[j])
+			i++
+			j++
+		case j < m && (i == n || lcs[i][j+1] >= lcs[i+1][j]):
+			d = append(d, "+ "+got[j])
+			j++
+		default:
+			d = append(d, fmt.Sprintf("- %s    (%s:%d)", want[i].text, want[i].pos.Filename, want[i].pos.Line))
+			i++
+		}
+	}
+	return d
+}
+
+// capture runs a section on a virtual clock set to clock.Playground, and
+// returns what it printed.
+func capture(s section.Section) string {
+	defer clock.Set(clock.NewVirtual(clock.Playground))()
+	return section.Capture(s.Run)
+}
+
+// runAndDiff runs a section and compares its output with its expectations,
+// returning the output and a diff, which is nil when they match.
+func runAndDiff(s section.Section, es []expectation) (string, []string) {
+	out := capture(s)
+	got := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if out == "" {
+		got = nil
+	}
+	return out, diff(match(es, got), got)
+}
+
+func verifyCmd(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	verbose := fs.Bool("v", false, "also list sections that pass or have nothing to check")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	check := section.All()
+	if fs.NArg() > 0 {
+		check = nil
+		for _, name := range fs.Args() {
+			found, err := section.Find(name)
+			if err != nil {
+				return err
+			}
+			check = append(check, found...)
+		}
+	}
+
+	failed := 0
+	for _, s := range check {
+		ss, err := source(s)
+		if err != nil {
+			return err
+		}
+		es := ss.expectations()
+		if len(es) == 0 {
+			if *verbose {
+				fmt.Printf("skip %s (no Prints comments)\n", s.Name())
+			}
+			continue
+		}
+
+		_, d := runAndDiff(s, es)
+		if d == nil {
+			if *verbose {
+				fmt.Printf("ok   %s\n", s.Name())
+			}
+			continue
+		}
+		failed++
+		fmt.Printf("FAIL %s (%s)\n", s.Name(), s.File)
+		for _, l := range d {
+			fmt.Printf("    %s\n", l)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d sections do not print what their comments say", failed, len(check))
+	}
+	return nil
+}